@@ -0,0 +1,170 @@
+package mono
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// AuthCallbackHandler receives request sent by MonoBank to X-Callback URL, when client grants access.
+// Callback is not authenticated, so status of the request is confirmed with CheckAuth before
+// session is marked as authorized. Hook is invoked once, when session becomes authorized
+// by the callback, in separate goroutine, so MonoBank gets response as fast as possible.
+// Sessions already authorized by CheckAuth or WaitForAuth don't invoke hook.
+type AuthCallbackHandler struct {
+	corporate *Corporate
+	hook      func(ctx context.Context, session *Session)
+
+	// Callbacks with the same request ID are serialized, so hook is not invoked twice.
+	mu    sync.Mutex
+	locks map[string]*requestLock
+}
+
+// requestLock serializes callbacks of a single request ID.
+type requestLock struct {
+	sync.Mutex
+	refs int
+}
+
+// NewAuthCallbackHandler returns new handler of corporate auth callbacks.
+// Sessions are looked up in the session store of corporate client, hook is called for each
+// authorized session and might be nil.
+func NewAuthCallbackHandler(corporate *Corporate, hook func(ctx context.Context, session *Session)) *AuthCallbackHandler {
+	return &AuthCallbackHandler{
+		corporate: corporate,
+		hook:      hook,
+		locks:     make(map[string]*requestLock),
+	}
+}
+
+// lock acquires lock of the request ID and returns function releasing it.
+func (h *AuthCallbackHandler) lock(reqID string) func() {
+	h.mu.Lock()
+	l, ok := h.locks[reqID]
+	if !ok {
+		l = new(requestLock)
+		h.locks[reqID] = l
+	}
+	l.refs++
+	h.mu.Unlock()
+
+	l.Lock()
+
+	return func() {
+		l.Unlock()
+
+		h.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(h.locks, reqID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// requestID extracts token request ID from header, query or JSON body of the callback.
+func (h *AuthCallbackHandler) requestID(w http.ResponseWriter, r *http.Request) string {
+	if reqID := r.Header.Get("X-Request-Id"); reqID != "" {
+		return reqID
+	}
+
+	if reqID := r.URL.Query().Get("requestId"); reqID != "" {
+		return reqID
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil || len(body) == 0 {
+		return ""
+	}
+
+	var data struct {
+		RequestID      string `json:"requestId"`
+		TokenRequestID string `json:"tokenRequestId"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return ""
+	}
+
+	if data.RequestID != "" {
+		return data.RequestID
+	}
+
+	return data.TokenRequestID
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *AuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	reqID := h.requestID(w, r)
+	if reqID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.authorize(r.Context(), reqID)
+	if errors.Is(err, ErrSessionNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(status)
+}
+
+// authorize confirms request with MonoBank and invokes hook, when session becomes authorized.
+// Already authorized sessions are not checked again, so replayed callbacks are no-op.
+func (h *AuthCallbackHandler) authorize(ctx context.Context, reqID string) (int, error) {
+	defer h.lock(reqID)()
+
+	sessions := h.corporate.SessionStore()
+
+	session, err := sessions.Get(ctx, reqID)
+	if err != nil {
+		return 0, err
+	}
+
+	if session.Authorized() {
+		return http.StatusOK, nil
+	}
+
+	// Session is persisted by CheckAuth only when access is granted.
+	status, err := h.corporate.CheckAuth(ctx, reqID)
+	if err != nil {
+		return 0, err
+	}
+
+	if status.State != AuthAccepted {
+		return http.StatusForbidden, nil
+	}
+
+	session, err = sessions.Get(ctx, reqID)
+	if err != nil {
+		return 0, err
+	}
+
+	if h.hook != nil && session.Authorized() {
+		go h.invoke(session)
+	}
+
+	return http.StatusOK, nil
+}
+
+// invoke calls hook, recovering from panic, so it does not crash the server.
+func (h *AuthCallbackHandler) invoke(session *Session) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.corporate.authCore.logf("mono: auth callback hook for %s panicked: %v", session.RequestID, r)
+		}
+	}()
+
+	h.hook(context.Background(), session)
+}
@@ -0,0 +1,197 @@
+package mono
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthCallbackHandler(t *testing.T) {
+	srv, rr := FakeServer(`{"status":"accepted","permissions":"sp"}`, http.StatusOK)
+	defer srv.Close()
+
+	corporate := newTestCorporate(t, WithBaseURL(srv.URL))
+
+	store := corporate.SessionStore()
+	if err := store.Put(context.Background(), NewSession("header", StatementPermission)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(context.Background(), NewSession("query", PersonalPermission)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(context.Background(), NewSession("body")); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions := make(chan *Session, 1)
+	handler := NewAuthCallbackHandler(corporate, func(ctx context.Context, session *Session) {
+		sessions <- session
+	})
+
+	header := httptest.NewRequest(http.MethodGet, "/callback", nil)
+	header.Header.Set("X-Request-Id", "header")
+
+	requests := map[string]*http.Request{
+		"header": header,
+		"query":  httptest.NewRequest(http.MethodGet, "/callback?requestId=query", nil),
+		"body":   httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(`{"requestId":"body"}`)),
+	}
+
+	for reqID, r := range requests {
+		t.Run("authorizes session from "+reqID, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+			assertEqual(t, http.StatusOK, rec.Code)
+
+			// Request is confirmed with MonoBank.
+			rr.AssertHeaders(t, map[string]string{"X-Request-Id": reqID})
+
+			select {
+			case session := <-sessions:
+				assertEqual(t, reqID, session.RequestID)
+				assertEqual(t, true, session.Authorized())
+			case <-time.After(time.Second):
+				t.Fatal("hook was not called")
+			}
+
+			session, err := store.Get(context.Background(), reqID)
+			assertEqual(t, nil, err)
+			assertEqual(t, true, session.Authorized())
+			assertEqual(t, "sp", session.Permissions)
+		})
+	}
+
+	t.Run("ignores replayed callback", func(t *testing.T) {
+		rr.Method = ""
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?requestId=query", nil))
+		assertEqual(t, http.StatusOK, rec.Code)
+		assertEqual(t, "", rr.Method)
+
+		select {
+		case <-sessions:
+			t.Error("hook must not be called")
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+
+	t.Run("rejects unknown request", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?requestId=unknown", nil))
+		assertEqual(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("rejects request without ID", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback", nil))
+		assertEqual(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestAuthCallbackHandler_NotAccepted(t *testing.T) {
+	srv, _ := FakeServer(`{"status":"pending"}`, http.StatusOK)
+	defer srv.Close()
+
+	corporate := newTestCorporate(t, WithBaseURL(srv.URL))
+	if err := corporate.SessionStore().Put(context.Background(), NewSession("reqID")); err != nil {
+		t.Fatal(err)
+	}
+
+	called := make(chan struct{}, 1)
+	handler := NewAuthCallbackHandler(corporate, func(ctx context.Context, session *Session) {
+		called <- struct{}{}
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?requestId=reqID", nil))
+	assertEqual(t, http.StatusForbidden, rec.Code)
+
+	session, err := corporate.SessionStore().Get(context.Background(), "reqID")
+	assertEqual(t, nil, err)
+	assertEqual(t, false, session.Authorized())
+
+	select {
+	case <-called:
+		t.Error("hook must not be called")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestAuthCallbackHandler_PanickingHook(t *testing.T) {
+	srv, _ := FakeServer(`{"status":"accepted"}`, http.StatusOK)
+	defer srv.Close()
+
+	corporate := newTestCorporate(t, WithBaseURL(srv.URL))
+	if err := corporate.SessionStore().Put(context.Background(), NewSession("reqID")); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	handler := NewAuthCallbackHandler(corporate, func(ctx context.Context, session *Session) {
+		close(done)
+		panic("hook failed")
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?requestId=reqID", nil))
+	assertEqual(t, http.StatusOK, rec.Code)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("hook was not called")
+	}
+}
+
+func TestAuthCallbackHandler_ConcurrentRequests(t *testing.T) {
+	arrived, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") == "slow" {
+			close(arrived)
+			<-release
+		}
+
+		_, _ = w.Write([]byte(`{"status":"accepted"}`))
+	}))
+	defer srv.Close()
+
+	corporate := newTestCorporate(t, WithBaseURL(srv.URL))
+	for _, reqID := range []string{"slow", "fast"} {
+		if err := corporate.SessionStore().Put(context.Background(), NewSession(reqID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := NewAuthCallbackHandler(corporate, nil)
+
+	slow := make(chan int, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?requestId=slow", nil))
+		slow <- rec.Code
+	}()
+	<-arrived
+
+	// Callback of another request doesn't wait for the slow one.
+	fast := make(chan int, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?requestId=fast", nil))
+		fast <- rec.Code
+	}()
+
+	select {
+	case code := <-fast:
+		assertEqual(t, http.StatusOK, code)
+	case <-time.After(time.Second):
+		t.Error("callback is blocked by another request")
+	}
+
+	close(release)
+	assertEqual(t, http.StatusOK, <-slow)
+	assertEqual(t, 0, len(handler.locks))
+}
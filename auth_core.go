@@ -4,10 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"time"
 )
@@ -22,42 +20,19 @@ type authCore struct {
 	auth Authorizer
 }
 
-func newAuthCore(auth Authorizer) *authCore {
-	return &authCore{
+func newAuthCore(auth Authorizer, identity string, opts ...Option) *authCore {
+	ac := &authCore{
 		auth: auth,
-		core: *newCore(),
+		core: *newCore(opts...),
 	}
+	ac.identity = identity
+
+	return ac
 }
 
 // GetJSON builds the full endpoint path and gets the raw JSON.
 func (ac *authCore) GetJSON(ctx context.Context, endpoint string, headers map[string]string) ([]byte, int, error) {
-	uri, err := ac.buildURL(endpoint)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	if err := ac.auth.Auth(r); err != nil {
-		return nil, 0, err
-	}
-
-	// Set headers.
-	for k, v := range headers {
-		r.Header.Set(k, v)
-	}
-
-	resp, err := ac.Do(r)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	return body, resp.StatusCode, err
+	return raw(ac.request(ctx, http.MethodGet, endpoint, headers, nil, ac.auth))
 }
 
 // PostJSON builds the full endpoint path and gets the raw JSON.
@@ -67,51 +42,28 @@ func (ac *authCore) PostJSON(
 	headers map[string]string,
 	payload io.Reader,
 ) ([]byte, int, error) {
-	uri, err := ac.buildURL(endpoint)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, "POST", uri, payload)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	if err := ac.auth.Auth(r); err != nil {
-		return nil, 0, err
-	}
-
-	// Set headers.
-	for k, v := range headers {
-		r.Header.Set(k, v)
-	}
-
-	resp, err := ac.Do(r)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer resp.Body.Close()
+	return raw(ac.request(ctx, http.MethodPost, endpoint, headers, payload, ac.auth))
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	return body, resp.StatusCode, err
+// call makes authorized HTTP request and returns response body, or *APIError if status is not successful.
+func (ac *authCore) call(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	headers map[string]string,
+	payload io.Reader,
+) ([]byte, error) {
+	return ac.core.call(ctx, method, endpoint, headers, payload, ac.auth)
 }
 
 // User returns user personal information from MonoBank API.
 // See https://api.monobank.ua/docs/#operation--personal-client-info-get for details.
 func (ac *authCore) User(ctx context.Context, headers map[string]string) (*UserInfo, error) {
-	body, status, err := ac.GetJSON(ctx, "/personal/client-info", headers)
+	body, err := ac.call(ctx, http.MethodGet, "/personal/client-info", headers, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if status != http.StatusOK {
-		var msg Error
-		if err := json.Unmarshal(body, &msg); err != nil {
-			return nil, errors.New("invalid error payload")
-		}
-		return nil, msg
-	}
-
 	var data UserInfo
 	if err = json.Unmarshal(body, &data); err != nil {
 		return nil, err
@@ -132,19 +84,11 @@ func (ac *authCore) Transactions(
 	error,
 ) {
 	path := fmt.Sprintf("/personal/statement/%s/%d/%d", account, from.Unix(), to.Unix())
-	body, status, err := ac.GetJSON(ctx, path, headers)
+	body, err := ac.call(ctx, http.MethodGet, path, headers, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if status != http.StatusOK {
-		var msg Error
-		if err := json.Unmarshal(body, &msg); err != nil {
-			return nil, errors.New("invalid error payload")
-		}
-		return nil, msg
-	}
-
 	var data []Transaction
 	if err = json.Unmarshal(body, &data); err != nil {
 		return nil, err
@@ -161,18 +105,10 @@ func (ac *authCore) SetWebHook(ctx context.Context, url string, headers map[stri
 		return nil, err
 	}
 
-	contents, status, err := ac.PostJSON(ctx, "/personal/webhook", headers, bytes.NewReader(buff))
+	contents, err := ac.call(ctx, http.MethodPost, "/personal/webhook", headers, bytes.NewReader(buff))
 	if err != nil {
 		return nil, err
 	}
 
-	if status != http.StatusOK {
-		var msg Error
-		if err := json.Unmarshal(contents, &msg); err != nil {
-			return nil, errors.New("invalid error payload")
-		}
-		return nil, msg
-	}
-
 	return contents, nil
 }
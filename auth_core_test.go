@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 type FakeAuthorizer struct{}
@@ -16,7 +17,7 @@ func (fake FakeAuthorizer) Auth(request *http.Request) error {
 }
 
 func TestAuthCore_GetJSON(t *testing.T) {
-	core := newAuthCore(FakeAuthorizer{})
+	core := newAuthCore(FakeAuthorizer{}, "")
 
 	srv, rr := FakeServer("Body", http.StatusOK)
 	core.SetBaseURL(srv.URL)
@@ -58,7 +59,7 @@ func TestAuthCore_GetJSON(t *testing.T) {
 }
 
 func TestAuthCore_PostJSON(t *testing.T) {
-	core := newAuthCore(FakeAuthorizer{})
+	core := newAuthCore(FakeAuthorizer{}, "")
 
 	srv, rr := FakeServer("Body", http.StatusOK)
 	core.SetBaseURL(srv.URL)
@@ -106,7 +107,7 @@ func TestAuthCore_Rates(t *testing.T) {
 		{
 			CodeA:     840,
 			CodeB:     980,
-			Date:      1552392228,
+			Date:      Time{time.Unix(1552392228, 0).UTC()},
 			RateSell:  27,
 			RateBuy:   27.2,
 			RateCross: 27.1,
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shal/mono"
+)
+
+// dateLayout is format of dates in tables and date-only arguments.
+const dateLayout = "2006-01-02"
+
+// defaultStatementPeriod is period of statement, when start is not specified.
+const defaultStatementPeriod = 30 * 24 * time.Hour
+
+func (a *app) runRates(ctx context.Context, args []string) error {
+	fs := a.flags("rates", usageRates)
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	rates, err := a.public().Rates(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(rates))
+	for _, ex := range rates {
+		rows = append(rows, []string{
+			currencyCode(ex.CodeA) + "/" + currencyCode(ex.CodeB),
+			formatRate(ex.RateBuy),
+			formatRate(ex.RateSell),
+			formatRate(ex.RateCross),
+			a.formatTime(ex.Date.Time),
+		})
+	}
+
+	return a.print(*asJSON, rates, []string{"PAIR", "BUY", "SELL", "CROSS", "DATE"}, rows)
+}
+
+func (a *app) runUser(ctx context.Context, args []string) error {
+	fs := a.flags("user", usageUser)
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	user, err := a.user(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{
+		{"ID", user.ID},
+		{"Name", user.Name},
+		{"Webhook", user.WebHookURL},
+		{"Accounts", strconv.Itoa(len(user.Accounts))},
+		{"Jars", strconv.Itoa(len(user.Jars))},
+	}
+
+	return a.print(*asJSON, user, nil, rows)
+}
+
+func (a *app) runAccounts(ctx context.Context, args []string) error {
+	fs := a.flags("accounts", usageAccounts)
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	user, err := a.user(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(user.Accounts))
+	for _, acc := range user.Accounts {
+		rows = append(rows, []string{
+			acc.ID,
+			string(acc.Type),
+			currencyCode(acc.CurrencyCode),
+			formatAmount(int64(acc.Balance), acc.CurrencyCode),
+			formatAmount(int64(acc.CreditLimit), acc.CurrencyCode),
+			acc.IBAN,
+			strings.Join(acc.MaskedPan, ","),
+		})
+	}
+
+	header := []string{"ID", "TYPE", "CURRENCY", "BALANCE", "CREDIT LIMIT", "IBAN", "CARDS"}
+	return a.print(*asJSON, user.Accounts, header, rows)
+}
+
+func (a *app) runJars(ctx context.Context, args []string) error {
+	fs := a.flags("jars", usageJars)
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	user, err := a.user(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(user.Jars))
+	for _, jar := range user.Jars {
+		code := int32(jar.CurrencyCode)
+		rows = append(rows, []string{
+			jar.ID,
+			jar.Title,
+			formatAmount(jar.Balance, code),
+			formatAmount(jar.Goal, code),
+		})
+	}
+
+	return a.print(*asJSON, user.Jars, []string{"ID", "TITLE", "BALANCE", "GOAL"}, rows)
+}
+
+func (a *app) runStatement(ctx context.Context, args []string) error {
+	fs := a.flags("statement", usageStatement)
+	asJSON := fs.Bool("json", false, "print JSON")
+	accountID := fs.String("account", "", "account ID or IBAN")
+	currency := fs.String("currency", "", "currency of the account, skips lookup of the account, so -account must be ID")
+	fromArg := fs.String("from", "", "start of the period (default 30 days before end)")
+	toArg := fs.String("to", "", "end of the period (default now)")
+	if err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	if *accountID == "" {
+		fmt.Fprintln(a.stderr, "mono: -account is required")
+		fs.Usage()
+		return errUsage
+	}
+
+	to := a.now()
+	if *toArg != "" {
+		t, err := a.parseTime(*toArg, true)
+		if err != nil {
+			return fmt.Errorf("invalid -to: %w", err)
+		}
+		to = t
+	}
+
+	from := to.Add(-defaultStatementPeriod)
+	if *fromArg != "" {
+		t, err := a.parseTime(*fromArg, false)
+		if err != nil {
+			return fmt.Errorf("invalid -from: %w", err)
+		}
+		from = t
+	}
+
+	if !from.Before(to) {
+		return fmt.Errorf("start of the period %s is not before end %s", a.formatTime(from), a.formatTime(to))
+	}
+
+	personal, err := a.personal()
+	if err != nil {
+		return err
+	}
+
+	account, err := findAccount(ctx, personal, *accountID, *currency)
+	if err != nil {
+		return err
+	}
+
+	transactions, err := personal.AllTransactions(ctx, account.ID, from, to)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(transactions))
+	for _, t := range transactions {
+		rows = append(rows, []string{
+			a.formatTime(t.Time.Time),
+			formatAmount(t.Amount, account.CurrencyCode),
+			formatAmount(t.Balance, account.CurrencyCode),
+			strconv.Itoa(int(t.MCC)),
+			strings.Join(strings.Fields(t.Description), " "),
+		})
+	}
+
+	return a.print(*asJSON, transactions, []string{"TIME", "AMOUNT", "BALANCE", "MCC", "DESCRIPTION"}, rows)
+}
+
+// findAccount returns account by ID or IBAN.
+// Statement doesn't contain currency of the account, so it's looked up in client info,
+// unless currency is specified. Client info is limited to one request per minute as well.
+func findAccount(ctx context.Context, personal *mono.Personal, id, currency string) (*mono.Account, error) {
+	if currency != "" {
+		ccy, err := mono.CurrencyFromCode(currency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -currency: %w", err)
+		}
+
+		code, err := ccy.ISO4217()
+		if err != nil {
+			return nil, fmt.Errorf("invalid -currency: %w", err)
+		}
+
+		return &mono.Account{ID: id, CurrencyCode: code}, nil
+	}
+
+	user, err := personal.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range user.Accounts {
+		if user.Accounts[i].ID == id || user.Accounts[i].IBAN == id {
+			return &user.Accounts[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("account %q is not found", id)
+}
+
+func (a *app) runWebhook(ctx context.Context, args []string) error {
+	fs := a.flags("webhook", usageWebhook)
+	if err := a.parse(fs, args, 2); err != nil {
+		return err
+	}
+
+	if fs.Arg(0) != "set" {
+		fmt.Fprintf(a.stderr, "mono: unknown webhook command %q\n", fs.Arg(0))
+		fs.Usage()
+		return errUsage
+	}
+
+	personal, err := a.personal()
+	if err != nil {
+		return err
+	}
+
+	url := fs.Arg(1)
+	if _, err := personal.SetWebHook(ctx, url); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Webhook is set to %s\n", url)
+	return nil
+}
+
+// user returns information about the client.
+func (a *app) user(ctx context.Context) (*mono.UserInfo, error) {
+	personal, err := a.personal()
+	if err != nil {
+		return nil, err
+	}
+
+	return personal.User(ctx)
+}
+
+// parseTime parses date or RFC 3339 time, end of the period includes the whole day.
+func (a *app) parseTime(s string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(dateLayout, s, a.location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither YYYY-MM-DD nor RFC 3339", s)
+	}
+
+	if end {
+		t = t.AddDate(0, 0, 1).Add(-time.Second)
+	}
+
+	return t, nil
+}
+
+// formatTime returns time in location of the tool.
+func (a *app) formatTime(t time.Time) string {
+	return t.In(a.location).Format(dateLayout + " 15:04:05")
+}
+
+// currencyCode returns alphabetic code of the currency, or numeric one if currency is unknown.
+func currencyCode(code int32) string {
+	ccy, err := mono.CurrencyFromISO4217(code)
+	if err != nil {
+		return strconv.Itoa(int(code))
+	}
+
+	return ccy.Code
+}
+
+// formatAmount formats amount in minor units of the currency.
+func formatAmount(amount int64, code int32) string {
+	ccy, err := mono.CurrencyFromISO4217(code)
+	if err != nil {
+		return strconv.FormatInt(amount, 10)
+	}
+
+	return mono.NewMoney(amount, ccy).String()
+}
+
+// formatRate formats rate, missing rates are shown as dash.
+func formatRate(rate float64) string {
+	if rate == 0 {
+		return "-"
+	}
+
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
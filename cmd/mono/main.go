@@ -0,0 +1,244 @@
+// Command mono gives access to MonoBank API from the command line.
+//
+// Usage:
+//
+//	mono <command> [flags] [arguments]
+//
+// Token of Personal API is read from MONO_TOKEN environment variable or from
+// JSON config file {"token": "..."} at MONO_CONFIG, which defaults to mono/config.json
+// in the user's config directory. Public commands don't require the token.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shal/mono"
+)
+
+// errUsage is returned by commands, when arguments are invalid and usage was printed.
+var errUsage = errors.New("invalid usage")
+
+// command is a subcommand of the tool.
+type command struct {
+	usage string
+	run   func(a *app, ctx context.Context, args []string) error
+}
+
+const (
+	usageRates     = "rates [-json]\n\tList currency rates."
+	usageUser      = "user [-json]\n\tShow information about the client."
+	usageAccounts  = "accounts [-json]\n\tList accounts of the client."
+	usageJars      = "jars [-json]\n\tList jars of the client."
+	usageStatement = "statement -account ID [-currency CODE] [-from DATE] [-to DATE] [-json]\n" +
+		"\tList transactions of the account, dates are YYYY-MM-DD or RFC 3339.\n" +
+		"\tWith -currency account is not looked up in client info, so -account must be ID."
+	usageWebhook = "webhook set URL\n\tSet URL for receiving new transactions."
+)
+
+var commands = map[string]command{
+	"rates":     {usageRates, (*app).runRates},
+	"user":      {usageUser, (*app).runUser},
+	"accounts":  {usageAccounts, (*app).runAccounts},
+	"jars":      {usageJars, (*app).runJars},
+	"statement": {usageStatement, (*app).runStatement},
+	"webhook":   {usageWebhook, (*app).runWebhook},
+}
+
+// app holds environment of the tool, so it can be replaced in tests.
+type app struct {
+	stdout   io.Writer
+	stderr   io.Writer
+	getenv   func(string) string
+	now      func() time.Time
+	location *time.Location
+	options  []mono.Option
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	a := &app{
+		stdout:   os.Stdout,
+		stderr:   os.Stderr,
+		getenv:   os.Getenv,
+		now:      time.Now,
+		location: time.Local,
+		// Statements of long periods are requested page by page within limits.
+		options: []mono.Option{mono.WithRateLimiter(mono.DefaultRateLimiter)},
+	}
+
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+// run executes command and returns exit code.
+func (a *app) run(ctx context.Context, args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		a.usage()
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(a.stderr, "mono: unknown command %q\n", args[0])
+		a.usage()
+		return 2
+	}
+
+	if err := cmd.run(a, ctx, args[1:]); errors.Is(err, errUsage) {
+		return 2
+	} else if err != nil {
+		fmt.Fprintf(a.stderr, "mono: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// usage prints list of commands.
+func (a *app) usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(a.stderr, "Usage: mono <command> [flags] [arguments]")
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(a.stderr, "  %s\n", strings.Replace(commands[name].usage, "\n", "\n  ", -1))
+	}
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, "Token is read from MONO_TOKEN or from config file at MONO_CONFIG.")
+}
+
+// flags returns flag set of the command, which prints usage of the command on errors.
+func (a *app) flags(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: mono %s\n", usage)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// parse parses arguments of the command, expecting exactly nargs positional arguments.
+func (a *app) parse(fs *flag.FlagSet, args []string, nargs int) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if fs.NArg() != nargs {
+		fs.Usage()
+		return errUsage
+	}
+
+	return nil
+}
+
+// config is a content of the config file.
+type config struct {
+	Token string `json:"token"`
+}
+
+// configPath returns path to the config file.
+func (a *app) configPath() (string, error) {
+	if path := a.getenv("MONO_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "mono", "config.json"), nil
+}
+
+// token returns token of Personal API from environment or config file.
+func (a *app) token() (string, error) {
+	if token := a.getenv("MONO_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	path, err := a.configPath()
+	if err != nil {
+		return "", fmt.Errorf("token is not set, export MONO_TOKEN: %w", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("token is not set, export MONO_TOKEN or add it to %s", path)
+	} else if err != nil {
+		return "", err
+	}
+
+	var cfg config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return "", fmt.Errorf("failed to decode config %s: %w", path, err)
+	}
+
+	if cfg.Token == "" {
+		return "", fmt.Errorf("token is not set in %s", path)
+	}
+
+	return cfg.Token, nil
+}
+
+// personal returns client of Personal API.
+func (a *app) personal() (*mono.Personal, error) {
+	token, err := a.token()
+	if err != nil {
+		return nil, err
+	}
+
+	return mono.NewPersonal(token, a.options...), nil
+}
+
+// public returns client of Public API.
+func (a *app) public() *mono.Public {
+	return mono.NewPublic(a.options...)
+}
+
+// print writes value as indented JSON or as a table with header.
+func (a *app) print(asJSON bool, v interface{}, header []string, rows [][]string) error {
+	if asJSON {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shal/mono"
+)
+
+func assertEqual(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}
+
+const userJSON = `{
+	"clientId": "client",
+	"name": "John Doe",
+	"webHookUrl": "https://example.com/hook",
+	"accounts": [
+		{"id": "uah", "balance": 123456, "creditLimit": 0, "currencyCode": 980, "type": "black", "iban": "UA00", "maskedPan": ["5375****1234"]},
+		{"id": "usd", "balance": 1000, "creditLimit": 0, "currencyCode": 840, "type": "black", "iban": "UA01"}
+	],
+	"jars": [{"id": "jar", "title": "Car", "currencyCode": 980, "balance": 5000, "goal": 100000}]
+}`
+
+// testServer serves fixed responses and records requests to MonoBank API.
+type testServer struct {
+	*httptest.Server
+	requests []string
+	tokens   []string
+	bodies   []string
+}
+
+func newTestServer() *testServer {
+	ts := new(testServer)
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		ts.requests = append(ts.requests, r.Method+" "+r.URL.Path)
+		ts.tokens = append(ts.tokens, r.Header.Get("X-Token"))
+		ts.bodies = append(ts.bodies, string(body))
+
+		switch {
+		case r.URL.Path == "/bank/currency":
+			w.Write([]byte(`[{"currencyCodeA":840,"currencyCodeB":980,"date":1552392228,"rateSell":27,"rateBuy":27.2},` +
+				`{"currencyCodeA":985,"currencyCodeB":980,"date":1552392228,"rateCross":7.1}]`))
+		case r.URL.Path == "/personal/client-info":
+			w.Write([]byte(userJSON))
+		case strings.HasPrefix(r.URL.Path, "/personal/statement/"):
+			w.Write([]byte(`[{"id":"tx","time":1552392228,"description":"Coffee\nshop","mcc":5814,"amount":-4550,"balance":118906}]`))
+		case r.URL.Path == "/personal/webhook":
+			w.Write([]byte(`{"status":"ok"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return ts
+}
+
+func newTestApp(srv *testServer, env map[string]string) (*app, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	a := &app{
+		stdout: stdout,
+		stderr: stderr,
+		getenv: func(key string) string {
+			return env[key]
+		},
+		now: func() time.Time {
+			return time.Date(2019, 3, 20, 0, 0, 0, 0, time.UTC)
+		},
+		location: time.UTC,
+		options:  []mono.Option{mono.WithBaseURL(srv.URL), mono.WithRateLimiter(nil)},
+	}
+
+	return a, stdout, stderr
+}
+
+func TestRun_Usage(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	for _, args := range [][]string{nil, {"help"}, {"unknown"}, {"rates", "extra"}, {"webhook", "get", "url"}} {
+		a, _, stderr := newTestApp(srv, nil)
+
+		assertEqual(t, 2, a.run(context.Background(), args))
+		if !strings.Contains(stderr.String(), "Usage: mono") {
+			t.Errorf("expected usage for %v, actual: %q", args, stderr.String())
+		}
+	}
+
+	assertEqual(t, 0, len(srv.requests))
+}
+
+func TestRun_Rates(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a, stdout, _ := newTestApp(srv, nil)
+
+	assertEqual(t, 0, a.run(context.Background(), []string{"rates"}))
+	assertEqual(t, []string{""}, srv.tokens)
+
+	expected := "" +
+		"PAIR     BUY   SELL  CROSS  DATE\n" +
+		"USD/UAH  27.2  27    -      2019-03-12 12:03:48\n" +
+		"PLN/UAH  -     -     7.1    2019-03-12 12:03:48\n"
+	assertEqual(t, expected, stdout.String())
+}
+
+func TestRun_RatesJSON(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a, stdout, _ := newTestApp(srv, nil)
+
+	assertEqual(t, 0, a.run(context.Background(), []string{"rates", "-json"}))
+	if !strings.Contains(stdout.String(), `"date": 1552392228`) {
+		t.Errorf("expected epoch date in JSON, actual: %s", stdout.String())
+	}
+}
+
+func TestRun_Accounts(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a, stdout, _ := newTestApp(srv, map[string]string{"MONO_TOKEN": "token"})
+
+	assertEqual(t, 0, a.run(context.Background(), []string{"accounts"}))
+	assertEqual(t, []string{"token"}, srv.tokens)
+
+	expected := "" +
+		"ID   TYPE   CURRENCY  BALANCE     CREDIT LIMIT  IBAN  CARDS\n" +
+		"uah  black  UAH       1 234,56 ₴  0,00 ₴        UA00  5375****1234\n" +
+		"usd  black  USD       10,00 $     0,00 $        UA01  \n"
+	assertEqual(t, expected, stdout.String())
+}
+
+func TestRun_Jars(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a, stdout, _ := newTestApp(srv, map[string]string{"MONO_TOKEN": "token"})
+
+	assertEqual(t, 0, a.run(context.Background(), []string{"jars"}))
+
+	expected := "" +
+		"ID   TITLE  BALANCE  GOAL\n" +
+		"jar  Car    50,00 ₴  1 000,00 ₴\n"
+	assertEqual(t, expected, stdout.String())
+}
+
+func TestRun_UserJSON(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a, stdout, _ := newTestApp(srv, map[string]string{"MONO_TOKEN": "token"})
+
+	assertEqual(t, 0, a.run(context.Background(), []string{"user", "-json"}))
+	if !strings.Contains(stdout.String(), `"name": "John Doe"`) {
+		t.Errorf("expected user in JSON, actual: %s", stdout.String())
+	}
+}
+
+func TestRun_Statement(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a, stdout, _ := newTestApp(srv, map[string]string{"MONO_TOKEN": "token"})
+
+	args := []string{"statement", "-account", "UA00", "-from", "2019-03-01", "-to", "2019-03-15"}
+	assertEqual(t, 0, a.run(context.Background(), args))
+
+	from := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+	to := time.Date(2019, 3, 15, 23, 59, 59, 0, time.UTC).Unix()
+	assertEqual(t, []string{
+		"GET /personal/client-info",
+		"GET /personal/statement/uah/" + strconv.FormatInt(from, 10) + "/" + strconv.FormatInt(to, 10),
+	}, srv.requests)
+
+	expected := "" +
+		"TIME                 AMOUNT    BALANCE     MCC   DESCRIPTION\n" +
+		"2019-03-12 12:03:48  -45,50 ₴  1 189,06 ₴  5814  Coffee shop\n"
+	assertEqual(t, expected, stdout.String())
+}
+
+func TestRun_StatementWithCurrency(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a, stdout, _ := newTestApp(srv, map[string]string{"MONO_TOKEN": "token"})
+
+	args := []string{"statement", "-account", "uah", "-currency", "uah", "-from", "2019-03-01", "-to", "2019-03-15"}
+	assertEqual(t, 0, a.run(context.Background(), args))
+
+	// Client info is not requested, so it's not limited after "mono accounts".
+	assertEqual(t, 1, len(srv.requests))
+	if !strings.HasPrefix(srv.requests[0], "GET /personal/statement/uah/") {
+		t.Errorf("expected request of statement, actual: %v", srv.requests)
+	}
+	if !strings.Contains(stdout.String(), "-45,50 ₴") {
+		t.Errorf("expected amount in UAH, actual: %s", stdout.String())
+	}
+}
+
+func TestRun_StatementErrors(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		args []string
+		code int
+		err  string
+	}{
+		{[]string{"statement"}, 2, "-account is required"},
+		{[]string{"statement", "-account", "uah", "-from", "March"}, 1, "invalid -from"},
+		{[]string{"statement", "-account", "uah", "-from", "2019-03-20", "-to", "2019-03-01"}, 1, "is not before end"},
+		{[]string{"statement", "-account", "eur"}, 1, `account "eur" is not found`},
+		{[]string{"statement", "-account", "eur", "-currency", "XYZ"}, 1, "invalid -currency"},
+	}
+
+	for _, tt := range tests {
+		a, _, stderr := newTestApp(srv, map[string]string{"MONO_TOKEN": "token"})
+
+		assertEqual(t, tt.code, a.run(context.Background(), tt.args))
+		if !strings.Contains(stderr.String(), tt.err) {
+			t.Errorf("expected error %q for %v, actual: %q", tt.err, tt.args, stderr.String())
+		}
+	}
+}
+
+func TestRun_WebhookSet(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a, stdout, _ := newTestApp(srv, map[string]string{"MONO_TOKEN": "token"})
+
+	assertEqual(t, 0, a.run(context.Background(), []string{"webhook", "set", "https://example.com/hook"}))
+	assertEqual(t, []string{"POST /personal/webhook"}, srv.requests)
+	assertEqual(t, `{"WebHookUrl":"https://example.com/hook"}`, srv.bodies[0])
+	assertEqual(t, "Webhook is set to https://example.com/hook\n", stdout.String())
+}
+
+func TestRun_TokenFromConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mono")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	if err := ioutil.WriteFile(path, []byte(`{"token":"config-token"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := newTestServer()
+	defer srv.Close()
+	a, _, _ := newTestApp(srv, map[string]string{"MONO_CONFIG": path})
+
+	assertEqual(t, 0, a.run(context.Background(), []string{"user"}))
+	assertEqual(t, []string{"config-token"}, srv.tokens)
+
+	t.Run("missing token", func(t *testing.T) {
+		a, _, stderr := newTestApp(srv, map[string]string{"MONO_CONFIG": filepath.Join(dir, "missing.json")})
+
+		assertEqual(t, 1, a.run(context.Background(), []string{"user"}))
+		if !strings.Contains(stderr.String(), "token is not set") {
+			t.Errorf("expected missing token error, actual: %q", stderr.String())
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package mono
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrNoRate is returned by Converter, when there is no rate for requested pair of currencies.
+var ErrNoRate = errors.New("no exchange rate")
+
+// hryvnia is numeric code of UAH, all rates of MonoBank are quoted against it.
+const hryvnia int32 = 980
+
+// RateKind is kind of the rate used for conversion.
+type RateKind string
+
+const (
+	// RateKindBuy is rate, at which bank buys base currency.
+	RateKindBuy RateKind = "buy"
+	// RateKindSell is rate, at which bank sells base currency.
+	RateKindSell RateKind = "sell"
+	// RateKindCross is cross rate, used when bank doesn't buy or sell the currency directly.
+	RateKindCross RateKind = "cross"
+)
+
+// ConversionLeg is a single step of conversion between two currencies.
+type ConversionLeg struct {
+	From     int32     // Numeric code of source currency.
+	To       int32     // Numeric code of target currency.
+	Kind     RateKind  // Kind of the rate used.
+	Rate     float64   // Units of target currency for one unit of source currency.
+	Date     time.Time // Time of the rate.
+	Exchange Exchange  // Original rate.
+}
+
+// Conversion is a result of conversion of amount from one currency to another.
+type Conversion struct {
+	From   int32           // Numeric code of source currency.
+	To     int32           // Numeric code of target currency.
+	Amount int64           // Converted amount in minor units of target currency.
+	Rate   float64         // Effective rate, units of target currency for one unit of source currency.
+	Legs   []ConversionLeg // Steps of conversion, two when converted through UAH.
+}
+
+// Date returns time of the oldest rate used for conversion.
+func (c *Conversion) Date() time.Time {
+	var date time.Time
+	for _, leg := range c.Legs {
+		if date.IsZero() || leg.Date.Before(date) {
+			date = leg.Date
+		}
+	}
+
+	return date
+}
+
+// Converter converts amounts between currencies using snapshot of MonoBank rates.
+type Converter struct {
+	rates map[[2]int32]Exchange
+}
+
+// NewConverter returns converter, which uses specified rates.
+func NewConverter(rates []Exchange) *Converter {
+	c := &Converter{
+		rates: make(map[[2]int32]Exchange, len(rates)),
+	}
+
+	for _, rate := range rates {
+		c.rates[[2]int32{rate.CodeA, rate.CodeB}] = rate
+	}
+
+	return c
+}
+
+// leg returns direct rate for conversion from one currency to another.
+// Converting base currency of the pair, client sells it to bank at buy rate.
+// Converting quote currency of the pair, client buys base currency from bank at sell rate.
+func (c *Converter) leg(from, to int32) (ConversionLeg, bool) {
+	if ex, ok := c.rates[[2]int32{from, to}]; ok {
+		leg := ConversionLeg{From: from, To: to, Date: ex.Date.Time, Exchange: ex}
+		switch {
+		case ex.RateBuy > 0:
+			leg.Kind, leg.Rate = RateKindBuy, ex.RateBuy
+		case ex.RateCross > 0:
+			leg.Kind, leg.Rate = RateKindCross, ex.RateCross
+		default:
+			return ConversionLeg{}, false
+		}
+		return leg, true
+	}
+
+	if ex, ok := c.rates[[2]int32{to, from}]; ok {
+		leg := ConversionLeg{From: from, To: to, Date: ex.Date.Time, Exchange: ex}
+		switch {
+		case ex.RateSell > 0:
+			leg.Kind, leg.Rate = RateKindSell, 1/ex.RateSell
+		case ex.RateCross > 0:
+			leg.Kind, leg.Rate = RateKindCross, 1/ex.RateCross
+		default:
+			return ConversionLeg{}, false
+		}
+		return leg, true
+	}
+
+	return ConversionLeg{}, false
+}
+
+// legs returns steps of conversion, falling back to triangulation through UAH.
+func (c *Converter) legs(from, to int32) ([]ConversionLeg, error) {
+	if leg, ok := c.leg(from, to); ok {
+		return []ConversionLeg{leg}, nil
+	}
+
+	if from != hryvnia && to != hryvnia {
+		first, ok1 := c.leg(from, hryvnia)
+		second, ok2 := c.leg(hryvnia, to)
+		if ok1 && ok2 {
+			return []ConversionLeg{first, second}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d/%d", ErrNoRate, from, to)
+}
+
+// Convert converts amount in minor units of one currency into minor units of another one.
+// Currencies are specified by numeric ISO 4217 codes, result is rounded half away from zero.
+func (c *Converter) Convert(amount int64, from, to int32) (*Conversion, error) {
+	src, err := CurrencyFromISO4217(from)
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := CurrencyFromISO4217(to)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := &Conversion{From: from, To: to, Amount: amount, Rate: 1}
+	if from == to {
+		return conversion, nil
+	}
+
+	legs, err := c.legs(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, leg := range legs {
+		conversion.Rate *= leg.Rate
+	}
+	conversion.Legs = legs
+
+	major := float64(amount) / math.Pow10(src.Exponent)
+	conversion.Amount = int64(math.Round(major * conversion.Rate * math.Pow10(dst.Exponent)))
+
+	return conversion, nil
+}
+
+// ConvertMoney converts money into another currency.
+func (c *Converter) ConvertMoney(m Money, to Currency) (Money, error) {
+	from, err := m.Currency.ISO4217()
+	if err != nil {
+		return Money{}, err
+	}
+
+	code, err := to.ISO4217()
+	if err != nil {
+		return Money{}, err
+	}
+
+	conversion, err := c.Convert(m.Amount, from, code)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(conversion.Amount, to), nil
+}
@@ -0,0 +1,89 @@
+package mono
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func testRates() Rates {
+	return Rates{
+		{CodeA: 840, CodeB: 980, Date: Time{time.Unix(1700000000, 0).UTC()}, RateBuy: 41.0, RateSell: 41.5},
+		{CodeA: 978, CodeB: 980, Date: Time{time.Unix(1700000100, 0).UTC()}, RateBuy: 44.5, RateSell: 45.2},
+		{CodeA: 978, CodeB: 840, Date: Time{time.Unix(1700000200, 0).UTC()}, RateBuy: 1.08, RateSell: 1.095},
+		{CodeA: 985, CodeB: 980, Date: Time{time.Unix(1699990000, 0).UTC()}, RateCross: 10.5},
+		{CodeA: 392, CodeB: 980, Date: Time{time.Unix(1700000000, 0).UTC()}, RateCross: 0.27},
+	}
+}
+
+func TestConverter_Convert(t *testing.T) {
+	converter := NewConverter(testRates())
+
+	tests := []struct {
+		name     string
+		amount   int64
+		from, to int32
+		expected int64
+		kinds    []RateKind
+	}{
+		{"same currency", 12345, 980, 980, 12345, nil},
+		{"sell base currency", 10000, 840, 980, 410000, []RateKind{RateKindBuy}},
+		{"buy base currency", 100000, 980, 840, 2410, []RateKind{RateKindSell}},
+		{"direct pair", 10000, 978, 840, 10800, []RateKind{RateKindBuy}},
+		{"reverse direct pair", 10000, 840, 978, 9132, []RateKind{RateKindSell}},
+		{"cross rate", 10000, 985, 980, 105000, []RateKind{RateKindCross}},
+		{"reverse cross rate", 105000, 980, 985, 10000, []RateKind{RateKindCross}},
+		{"through hryvnia", 10000, 985, 840, 2530, []RateKind{RateKindCross, RateKindSell}},
+		{"exponent", 1000, 392, 980, 27000, []RateKind{RateKindCross}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, err := converter.Convert(tt.amount, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("expected error: nil, actual error: %v", err)
+			}
+
+			assertEqual(t, tt.expected, conversion.Amount)
+
+			var kinds []RateKind
+			for _, leg := range conversion.Legs {
+				kinds = append(kinds, leg.Kind)
+			}
+			assertEqual(t, tt.kinds, kinds)
+		})
+	}
+}
+
+func TestConverter_Convert_Date(t *testing.T) {
+	conversion, err := NewConverter(testRates()).Convert(10000, 985, 840)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, time.Unix(1699990000, 0).UTC(), conversion.Date())
+}
+
+func TestConverter_Convert_NoRate(t *testing.T) {
+	converter := NewConverter(testRates())
+
+	if _, err := converter.Convert(100, 826, 840); !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected error: %v, actual error: %v", ErrNoRate, err)
+	}
+
+	if _, err := converter.Convert(100, 1, 840); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("expected error: %v, actual error: %v", ErrUnknownCurrency, err)
+	}
+}
+
+func TestConverter_ConvertMoney(t *testing.T) {
+	uah, _ := CurrencyFromCode("UAH")
+	usd, _ := CurrencyFromCode("USD")
+
+	money, err := NewConverter(testRates()).ConvertMoney(NewMoney(10000, usd), uah)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, NewMoney(410000, uah), money)
+}
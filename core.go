@@ -1,14 +1,15 @@
 package mono
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 )
 
@@ -18,7 +19,13 @@ const DefaultBaseURL = "https://api.monobank.ua"
 type core struct {
 	http.Client
 
-	baseURL string
+	baseURL   string
+	userAgent string
+	logger    Logger
+	limiter   *RateLimiter
+	retry     *RetryPolicy
+	identity  string
+	rates     *RatesCache
 }
 
 func (c *core) buildURL(endpoint string) (string, error) {
@@ -27,13 +34,18 @@ func (c *core) buildURL(endpoint string) (string, error) {
 		return "", err
 	}
 
-	baseURL.Path = path.Join(baseURL.Path, endpoint)
+	parts := strings.SplitN(endpoint, "?", 2)
+	baseURL.Path = path.Join(baseURL.Path, parts[0])
+	if len(parts) > 1 {
+		baseURL.RawQuery = parts[1]
+	}
+
 	return baseURL.String(), nil
 }
 
 // newCore creates a new MonoBank client with some reasonable HTTP request defaults.
-func newCore() *core {
-	return &core{
+func newCore(opts ...Option) *core {
+	c := &core{
 		baseURL: DefaultBaseURL,
 		Client: http.Client{
 			Timeout: time.Second * 5,
@@ -43,18 +55,101 @@ func newCore() *core {
 			},
 		},
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
-// GetJSON builds the full endpoint path and gets the raw JSON.
-func (c *core) GetJSON(ctx context.Context, endpoint string, headers map[string]string) ([]byte, int, error) {
+func (c *core) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
+}
+
+// response is a raw HTTP response of MonoBank API.
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// request makes HTTP request, repeating it according to the retry policy.
+func (c *core) request(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	headers map[string]string,
+	payload io.Reader,
+	auth Authorizer,
+) (*response, error) {
+	if c.retry == nil || c.retry.MaxAttempts <= 1 || !isRetrySafe(ctx, method) {
+		return c.attempt(ctx, method, endpoint, headers, payload, auth)
+	}
+
+	// Payload has to be read again on each attempt.
+	var data []byte
+	if payload != nil {
+		var err error
+		if data, err = ioutil.ReadAll(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if data != nil {
+			payload = bytes.NewReader(data)
+		}
+
+		resp, err := c.attempt(ctx, method, endpoint, headers, payload, auth)
+		if attempt >= c.retry.MaxAttempts || ctx.Err() != nil || !c.retry.retryable(resp, err) {
+			return resp, err
+		}
+
+		delay := c.retry.delay(attempt, resp)
+		c.logf("mono: retrying %s %s in %s, attempt %d", method, endpoint, delay, attempt+1)
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt builds the full endpoint path, waits for the rate limiter and makes HTTP request.
+func (c *core) attempt(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	headers map[string]string,
+	payload io.Reader,
+	auth Authorizer,
+) (*response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx, limiterKey(c.identity, headers["X-Request-Id"]), endpoint); err != nil {
+			return nil, err
+		}
+	}
+
 	uri, err := c.buildURL(endpoint)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	r, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	r, err := http.NewRequestWithContext(ctx, method, uri, payload)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+
+	if auth != nil {
+		if err := auth.Auth(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.userAgent != "" {
+		r.Header.Set("User-Agent", c.userAgent)
 	}
 
 	// Set headers.
@@ -62,65 +157,111 @@ func (c *core) GetJSON(ctx context.Context, endpoint string, headers map[string]
 		r.Header.Set(k, v)
 	}
 
+	start := time.Now()
 	resp, err := c.Do(r)
 	if err != nil {
-		return nil, 0, err
+		c.logf("mono: %s %s: %v", method, endpoint, err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
+	c.logf("mono: %s %s: %d in %s", method, endpoint, resp.StatusCode, time.Since(start))
+
 	body, err := ioutil.ReadAll(resp.Body)
-	return body, resp.StatusCode, err
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{
+		status: resp.StatusCode,
+		header: resp.Header,
+		body:   body,
+	}, nil
 }
 
-// PostJSON builds the full endpoint path and gets the raw JSON.
-func (c *core) PostJSON(
+// call makes HTTP request and returns response body, or *APIError if status is not successful.
+func (c *core) call(
 	ctx context.Context,
+	method string,
 	endpoint string,
 	headers map[string]string,
 	payload io.Reader,
-) ([]byte, int, error) {
-	uri, err := c.buildURL(endpoint)
+	auth Authorizer,
+) ([]byte, error) {
+	resp, err := c.request(ctx, method, endpoint, headers, payload, auth)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	r, err := http.NewRequestWithContext(ctx, "POST", uri, payload)
-	if err != nil {
-		return nil, 0, err
+	if resp.status != http.StatusOK {
+		return nil, newAPIError(method, endpoint, resp)
 	}
 
-	// Set headers.
-	for k, v := range headers {
-		r.Header.Set(k, v)
-	}
+	return resp.body, nil
+}
 
-	resp, err := c.Do(r)
+// raw converts response into the form returned by GetJSON and PostJSON.
+func raw(resp *response, err error) ([]byte, int, error) {
 	if err != nil {
 		return nil, 0, err
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
-	return body, resp.StatusCode, err
+	return resp.body, resp.status, nil
+}
+
+// GetJSON builds the full endpoint path and gets the raw JSON.
+func (c *core) GetJSON(ctx context.Context, endpoint string, headers map[string]string) ([]byte, int, error) {
+	return raw(c.request(ctx, http.MethodGet, endpoint, headers, nil, nil))
+}
+
+// PostJSON builds the full endpoint path and gets the raw JSON.
+func (c *core) PostJSON(
+	ctx context.Context,
+	endpoint string,
+	headers map[string]string,
+	payload io.Reader,
+) ([]byte, int, error) {
+	return raw(c.request(ctx, http.MethodPost, endpoint, headers, payload, nil))
 }
 
 // Rates returns list of currencies rates from MonoBank API.
 // See https://api.monobank.ua/docs/#/definitions/CurrencyInfo for details.
-func (c *core) Rates(ctx context.Context) ([]Exchange, error) {
-	contents, status, err := c.GetJSON(ctx, "/bank/currency", nil)
+func (c *core) Rates(ctx context.Context) (Rates, error) {
+	if c.rates == nil {
+		return c.fetchRates(ctx)
+	}
+
+	cached, err := c.rates.get(ctx, c.fetchRates)
 	if err != nil {
 		return nil, err
 	}
 
-	if status != http.StatusOK {
-		var msg Error
-		if err := json.Unmarshal(contents, &msg); err != nil {
-			return nil, errors.New("invalid error payload")
+	return cached.Rates, nil
+}
+
+// CachedRates returns currency rates with information about their freshness.
+// Without rates cache, rates are always requested from MonoBank API.
+func (c *core) CachedRates(ctx context.Context) (*CachedRates, error) {
+	if c.rates == nil {
+		rates, err := c.fetchRates(ctx)
+		if err != nil {
+			return nil, err
 		}
-		return nil, msg
+
+		return &CachedRates{Rates: rates, FetchedAt: time.Now()}, nil
 	}
 
-	var data []Exchange
+	return c.rates.get(ctx, c.fetchRates)
+}
+
+// fetchRates requests currency rates from MonoBank API.
+func (c *core) fetchRates(ctx context.Context) (Rates, error) {
+	contents, err := c.call(ctx, http.MethodGet, "/bank/currency", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var data Rates
 	if err = json.Unmarshal(contents, &data); err != nil {
 		return nil, err
 	}
@@ -132,3 +273,20 @@ func (c *core) Rates(ctx context.Context) ([]Exchange, error) {
 func (c *core) SetBaseURL(url string) {
 	c.baseURL = url
 }
+
+// SetRateLimiter replaces rate limiter of the client.
+// Limiter can be shared between clients, nil disables rate limiting.
+func (c *core) SetRateLimiter(limiter *RateLimiter) {
+	c.limiter = limiter
+}
+
+// SetRetryPolicy sets policy of retrying failed requests, nil disables retries.
+func (c *core) SetRetryPolicy(policy *RetryPolicy) {
+	c.retry = policy
+}
+
+// SetRatesCache sets cache of currency rates, which can be shared between clients.
+// Nil disables caching.
+func (c *core) SetRatesCache(cache *RatesCache) {
+	c.rates = cache
+}
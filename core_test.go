@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 )
 
 type RequestRecorder struct {
@@ -146,7 +147,7 @@ func TestCore_Rates(t *testing.T) {
 		{
 			CodeA:     840,
 			CodeB:     980,
-			Date:      1552392228,
+			Date:      Time{time.Unix(1552392228, 0).UTC()},
 			RateSell:  27,
 			RateBuy:   27.2,
 			RateCross: 27.1,
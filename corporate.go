@@ -1,11 +1,10 @@
 package mono
 
 import (
+	"bytes"
 	"context"
+	"crypto"
 	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/sha1"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +12,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,14 +23,76 @@ const (
 	PersonalPermission byte = 'p'
 )
 
+// DefaultAuthPollInterval is interval between checks of request status used by WaitForAuth.
+const DefaultAuthPollInterval = 5 * time.Second
+
+// AuthState is a state of request for client's personal data.
+type AuthState string
+
+const (
+	// AuthPending means, that client has not confirmed request yet.
+	AuthPending AuthState = "pending"
+	// AuthAccepted means, that client granted access.
+	AuthAccepted AuthState = "accepted"
+	// AuthRejected means, that client declined request.
+	AuthRejected AuthState = "rejected"
+	// AuthExpired means, that client has not confirmed request in time.
+	AuthExpired AuthState = "expired"
+)
+
+// ErrUnknownAuthState is returned, when MonoBank reports state of request, which is not known by the client.
+var ErrUnknownAuthState = errors.New("unknown auth state")
+
+// known reports whether state is one of documented states.
+func (s AuthState) known() bool {
+	switch s {
+	case AuthPending, AuthAccepted, AuthRejected, AuthExpired:
+		return true
+	}
+
+	return false
+}
+
+// UnmarshalJSON decodes state case-insensitively, treating "declined" as rejected.
+func (s *AuthState) UnmarshalJSON(b []byte) error {
+	var state string
+	if err := json.Unmarshal(b, &state); err != nil {
+		return err
+	}
+
+	*s = AuthState(strings.ToLower(state))
+	if *s == "declined" {
+		*s = AuthRejected
+	}
+
+	return nil
+}
+
+// AuthStatus is a status of request for client's personal data.
+type AuthStatus struct {
+	State       AuthState `json:"status"`      // State of the request.
+	Permissions string    `json:"permissions"` // Permissions granted by client.
+}
+
+// Final reports whether request will not change it's state anymore.
+func (s *AuthStatus) Final() bool {
+	return s.State == AuthAccepted || s.State == AuthRejected || s.State == AuthExpired
+}
+
+// Allows reports whether client granted specified permission.
+func (s *AuthStatus) Allows(permission byte) bool {
+	return s.State == AuthAccepted && strings.IndexByte(s.Permissions, permission) >= 0
+}
+
 type corporateAuth struct {
 	*SignTool
-	PrivateKey *ecdsa.PrivateKey
-	KeyID      string
+	Signer    crypto.Signer
+	PublicKey *ecdsa.PublicKey
+	KeyID     string
 }
 
 func (auth *corporateAuth) signStrings(params ...string) (string, error) {
-	return auth.Sign(auth.PrivateKey, strings.Join(params, ""))
+	return auth.SignWith(auth.Signer, strings.Join(params, ""))
 }
 
 func (auth *corporateAuth) Auth(r *http.Request) error {
@@ -42,10 +104,37 @@ func (auth *corporateAuth) Auth(r *http.Request) error {
 	return nil
 }
 
+// requestSigner signs each attempt of the request right before it's sent,
+// so X-Sign matches X-Time after waiting for rate limiter or retry.
+type requestSigner struct {
+	auth   *corporateAuth
+	params []string
+}
+
+// Auth sets X-Time and X-Sign of timestamp followed by signed params.
+func (s *requestSigner) Auth(r *http.Request) error {
+	timestamp := strconv.Itoa(int(time.Now().Unix()))
+
+	sign, err := s.auth.signStrings(append([]string{timestamp}, s.params...)...)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("X-Key-Id", s.auth.KeyID)
+	r.Header.Set("X-Time", timestamp)
+	r.Header.Set("X-Sign", sign)
+
+	return nil
+}
+
 // Corporate gives access to corporate methods.
 type Corporate struct {
 	authCore authCore
 	auth     corporateAuth
+	sessions SessionStore
+
+	// sessionMu serializes updates of sessions, so concurrent changes are not overwritten.
+	sessionMu sync.Mutex
 }
 
 func newCorporateAuth(
@@ -55,55 +144,77 @@ func newCorporateAuth(
 
 	privateKey, err := sign.DecodePrivateKey(keyData)
 	if err != nil {
-		return nil, errors.New("failed to decode private key")
+		return nil, fmt.Errorf("failed to decode private key: %w", err)
 	}
 
-	publicKey := privateKey.PublicKey
-	data := elliptic.Marshal(publicKey, publicKey.X, publicKey.Y)
-	hash := sha1.New()
-	if _, err := hash.Write(data); err != nil {
-		return nil, errors.New("failed to encode public key with sha1")
+	return newSignerAuth(sign, sign.Signer(privateKey))
+}
+
+func newSignerAuth(sign *SignTool, signer crypto.Signer) (*corporateAuth, error) {
+	publicKey, ok := signer.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("signer must use ECDSA key")
 	}
-	keyID := hex.EncodeToString(hash.Sum(nil))
 
 	return &corporateAuth{
-		SignTool:   sign,
-		PrivateKey: privateKey,
-		KeyID:      keyID,
+		SignTool:  sign,
+		Signer:    signer,
+		PublicKey: publicKey,
+		KeyID:     KeyID(publicKey),
 	}, nil
 }
 
+// signed returns client, which signs requests with params following the timestamp.
+func (c *Corporate) signed(params ...string) *authCore {
+	return &authCore{
+		core: c.authCore.core,
+		auth: &requestSigner{auth: &c.auth, params: params},
+	}
+}
+
+func newCorporate(auth *corporateAuth, opts ...Option) *Corporate {
+	return &Corporate{
+		auth:     *auth,
+		authCore: *newAuthCore(auth, auth.KeyID, opts...),
+		sessions: NewMemorySessionStore(),
+	}
+}
+
 // NewCorporate returns new client of MonoBank Corporate API.
-func NewCorporate(keyData []byte) (*Corporate, error) {
+func NewCorporate(keyData []byte, opts ...Option) (*Corporate, error) {
 	auth, err := newCorporateAuth(keyData)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Corporate{
-		auth:     *auth,
-		authCore: *newAuthCore(auth),
-	}, nil
+	return newCorporate(auth, opts...), nil
+}
+
+// NewCorporateWithSigner returns new client of MonoBank Corporate API, which signs requests with signer.
+// It allows to keep private key in signing agent, hardware token or vault instead of process memory.
+// Signer must hold ECDSA key on secp256k1 curve and return ASN.1 encoded signatures of SHA-256 digests.
+func NewCorporateWithSigner(signer crypto.Signer, opts ...Option) (*Corporate, error) {
+	auth, err := newSignerAuth(DefaultSignTool(), signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCorporate(auth, opts...), nil
 }
 
 // Auth initializes access.
+// Issued request is recorded in the session store of the client. If session is not saved,
+// token request is returned together with the error, so request ID is not lost.
 func (c *Corporate) Auth(ctx context.Context, callback string, permissions ...byte) (*TokenRequest, error) {
-	timestamp := strconv.Itoa(int(time.Now().Unix()))
 	pp := string(permissions)
 	endpoint := "/personal/auth/request"
 
-	sign, err := c.auth.signStrings(timestamp, pp, endpoint)
-	if err != nil {
-		return nil, err
-	}
-
 	headers := map[string]string{
 		"X-Permissions": pp,
-		"X-Sign":        sign,
 		"X-Callback":    callback,
 	}
 
-	body, status, err := c.authCore.PostJSON(ctx, endpoint, headers, nil)
+	body, err := c.signed(pp, endpoint).call(ctx, http.MethodPost, endpoint, headers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -113,93 +224,161 @@ func (c *Corporate) Auth(ctx context.Context, callback string, permissions ...by
 		return nil, err
 	}
 
-	if status != http.StatusOK {
-		var msg Error
-		if err := json.Unmarshal(body, &msg); err != nil {
-			return nil, errors.New("invalid error payload")
-		}
-		return nil, msg
+	// Request is already issued, so it's returned even if session is not saved.
+	if err := c.sessions.Put(ctx, NewSession(tokenRequest.TokenRequestID, permissions...)); err != nil {
+		return tokenRequest, fmt.Errorf("failed to save session %s: %w", tokenRequest.TokenRequestID, err)
 	}
 
 	return tokenRequest, nil
 }
 
 // CheckAuth checks status of request for client's personal data.
-func (c *Corporate) CheckAuth(ctx context.Context, reqID string) (bool, error) {
-	timestamp := strconv.Itoa(int(time.Now().Unix()))
+// ErrUnknownAuthState is returned, when state of the request is not known.
+func (c *Corporate) CheckAuth(ctx context.Context, reqID string) (*AuthStatus, error) {
 	endpoint := "/personal/auth/request"
 
-	sign, err := c.auth.signStrings(timestamp, reqID, endpoint)
-	if err != nil {
-		return false, err
-	}
-
 	headers := map[string]string{
-		"X-Sign":       sign,
 		"X-Request-Id": reqID,
 	}
 
-	body, status, err := c.authCore.GetJSON(ctx, endpoint, headers)
+	body, err := c.signed(reqID, endpoint).call(ctx, http.MethodGet, endpoint, headers, nil)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	status := new(AuthStatus)
+	if len(bytes.TrimSpace(body)) != 0 {
+		if err := json.Unmarshal(body, status); err != nil {
+			return nil, err
+		}
+	}
+
+	// Successful response without explicit state means, that access is granted.
+	if status.State == "" {
+		status.State = AuthAccepted
+	}
+
+	// Unknown state can't be treated as final, so it's reported instead of being polled forever.
+	if !status.State.known() {
+		return nil, fmt.Errorf("%w: %q of request %s", ErrUnknownAuthState, status.State, reqID)
+	}
+
+	if status.State == AuthAccepted {
+		if err := c.authorizeSession(ctx, reqID, status); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// WaitForAuth polls status of request for client's personal data every {interval}
+// until client accepts or rejects it, request expires or context is done.
+// With rate limiter polls are not made more often than the limit of the endpoint allows.
+func (c *Corporate) WaitForAuth(ctx context.Context, reqID string, interval time.Duration) (*AuthStatus, error) {
+	if interval <= 0 {
+		interval = DefaultAuthPollInterval
 	}
 
-	if status != http.StatusOK {
-		var msg Error
-		if err := json.Unmarshal(body, &msg); err != nil {
-			return false, errors.New("invalid error payload")
+	for {
+		status, err := c.CheckAuth(ctx, reqID)
+		if err != nil {
+			return nil, err
 		}
-		return false, msg
+
+		if status.Final() {
+			return status, nil
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// authorizeSession marks known session as authorized with permissions granted by client.
+func (c *Corporate) authorizeSession(ctx context.Context, reqID string, status *AuthStatus) error {
+	err := c.updateSession(ctx, reqID, func(session *Session) bool {
+		if session.Authorized() && (status.Permissions == "" || status.Permissions == session.Permissions) {
+			return false
+		}
+
+		if !session.Authorized() {
+			session.AuthorizedAt = time.Now().UTC()
+		}
+		if status.Permissions != "" {
+			session.Permissions = status.Permissions
+		}
+
+		return true
+	})
+	if errors.Is(err, ErrSessionNotFound) {
+		return nil
 	}
 
-	return true, nil
+	return err
+}
+
+// updateSession reads session, applies update and saves it, when update reports changes.
+func (c *Corporate) updateSession(ctx context.Context, reqID string, update func(session *Session) bool) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	session, err := c.sessions.Get(ctx, reqID)
+	if err != nil {
+		return err
+	}
+
+	if !update(session) {
+		return nil
+	}
+
+	return c.sessions.Put(ctx, session)
 }
 
 // User returns user personal information from MonoBank API.
 // See https://api.monobank.ua/docs/#/definitions/UserInfo for details.
 func (c *Corporate) User(ctx context.Context, reqID string) (*UserInfo, error) {
-	timestamp := strconv.Itoa(int(time.Now().Unix()))
 	endpoint := "/personal/client-info"
 
-	sign, err := c.auth.signStrings(timestamp, reqID, endpoint)
-	if err != nil {
-		return nil, err
-	}
-
 	headers := map[string]string{
-		"X-Sign":       sign,
 		"X-Request-Id": reqID,
 	}
 
-	return c.authCore.User(ctx, headers)
+	return c.signed(reqID, endpoint).User(ctx, headers)
 }
 
 // Transactions returns list of transactions from {from} till {to} time.
 // See https://api.monobank.ua/docs/#/definitions/StatementItems for details.
 func (c *Corporate) Transactions(ctx context.Context, reqID string, account string, from, to time.Time) ([]Transaction, error) {
-	timestamp := strconv.Itoa(int(time.Now().Unix()))
-	fmt.Println()
 	path := fmt.Sprintf("/personal/statement/%s/%d/%d", account, from.Unix(), to.Unix())
 
-	sign, err := c.auth.signStrings(timestamp, reqID, path)
-	if err != nil {
-		return nil, err
-	}
-
 	headers := map[string]string{
-		"X-Sign":       sign,
 		"X-Request-Id": reqID,
 	}
 
-	return c.authCore.Transactions(ctx, account, from, to, headers)
+	return c.signed(reqID, path).Transactions(ctx, account, from, to, headers)
+}
+
+// AllTransactions returns complete list of transactions from {from} till {to} time.
+// Unlike Transactions, range is not limited by MonoBank API and result is sorted chronologically.
+func (c *Corporate) AllTransactions(ctx context.Context, reqID string, account string, from, to time.Time) ([]Transaction, error) {
+	return allTransactions(ctx, from, to, func(ctx context.Context, from, to time.Time) ([]Transaction, error) {
+		return c.Transactions(ctx, reqID, account, from, to)
+	})
 }
 
 // Rates returns list of currencies rates from MonoBank API.
 // See https://api.monobank.ua/docs/#/definitions/CurrencyInfo for details.
-func (c *Corporate) Rates(ctx context.Context) ([]Exchange, error) {
+func (c *Corporate) Rates(ctx context.Context) (Rates, error) {
 	return c.authCore.Rates(ctx)
 }
 
+// CachedRates returns currency rates with information about their freshness.
+func (c *Corporate) CachedRates(ctx context.Context) (*CachedRates, error) {
+	return c.authCore.CachedRates(ctx)
+}
+
 // GetJSON builds the full endpoint path and gets the raw JSON.
 func (c *Corporate) GetJSON(ctx context.Context, endpoint string, headers map[string]string) ([]byte, int, error) {
 	return c.authCore.GetJSON(ctx, endpoint, headers)
@@ -209,3 +388,37 @@ func (c *Corporate) GetJSON(ctx context.Context, endpoint string, headers map[st
 func (c *Corporate) PostJSON(ctx context.Context, endpoint string, headers map[string]string, payload io.Reader) ([]byte, int, error) {
 	return c.authCore.PostJSON(ctx, endpoint, headers, payload)
 }
+
+// SetRateLimiter replaces rate limiter of the client.
+// Limiter can be shared between clients, nil disables rate limiting.
+func (c *Corporate) SetRateLimiter(limiter *RateLimiter) {
+	c.authCore.SetRateLimiter(limiter)
+}
+
+// SetRetryPolicy sets policy of retrying failed requests, nil disables retries.
+func (c *Corporate) SetRetryPolicy(policy *RetryPolicy) {
+	c.authCore.SetRetryPolicy(policy)
+}
+
+// SetRatesCache sets cache of currency rates, which can be shared between clients.
+func (c *Corporate) SetRatesCache(cache *RatesCache) {
+	c.authCore.SetRatesCache(cache)
+}
+
+// SetSessionStore replaces store of sessions issued by Auth.
+func (c *Corporate) SetSessionStore(store SessionStore) {
+	c.sessions = store
+}
+
+// SessionStore returns store of sessions issued by Auth.
+func (c *Corporate) SessionStore() SessionStore {
+	return c.sessions
+}
+
+// Session returns handle for accessing client's data with the request ID.
+func (c *Corporate) Session(reqID string) *CorporateSession {
+	return &CorporateSession{
+		corporate: c,
+		reqID:     reqID,
+	}
+}
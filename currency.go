@@ -1,97 +1,69 @@
 package mono
 
-import "errors"
-
-var currencyCodes = map[int32]Currency{
-	840: {
-		Name:   "US Dollar",
-		Code:   "USD",
-		Symbol: "$",
-	},
-	980: {
-		Name:   "Hryvnia",
-		Code:   "UAH",
-		Symbol: "₴",
-	},
-	978: {
-		Name:   "Euro",
-		Code:   "EUR",
-		Symbol: "€",
-	},
-	643: {
-		Name:   "Russian Ruble",
-		Code:   "RUB",
-		Symbol: "₽",
-	},
-	826: {
-		Name:   "Pound Sterling",
-		Code:   "GBP",
-		Symbol: "£",
-	},
-	756: {
-		Name:   "Swiss Franc",
-		Code:   "CHF",
-		Symbol: "₣",
-	},
-	933: {
-		Name:   "Belarussian Ruble",
-		Code:   "BYN",
-		Symbol: "Br",
-	},
-	124: {
-		Name:   "Canadian Dollar",
-		Code:   "CAD",
-		Symbol: "$",
-	},
-	203: {
-		Name:   "Czech Koruna",
-		Code:   "CZK",
-		Symbol: "Kč",
-	},
-	208: {
-		Name:   "Danish Krone",
-		Code:   "DKK",
-		Symbol: "Kr",
-	},
-	348: {
-		Name:   "Forint",
-		Code:   "HUF",
-		Symbol: "Ft",
-	},
-	985: {
-		Name:   "Zloty",
-		Code:   "PLN",
-		Symbol: "zł",
-	},
-	949: {
-		Name:   "Turkish Lira",
-		Code:   "TRY",
-		Symbol: "₺",
-	},
-}
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//go:generate go run gen_currencies.go
+
+// ErrUnknownCurrency is returned for codes, which are not listed in ISO 4217.
+var ErrUnknownCurrency = errors.New("unknown currency code")
+
+// currencyAlphaCodes maps alphabetic codes of currencies into numeric ones.
+var currencyAlphaCodes = func() map[string]int32 {
+	codes := make(map[string]int32, len(currencyCodes))
+	for num, ccy := range currencyCodes {
+		codes[ccy.Code] = num
+	}
+	return codes
+}()
 
 // Currency is internal representation of fiat currencies.
 type Currency struct {
-	Name   string
-	Code   string
-	Symbol string
+	Name     string
+	Code     string
+	Symbol   string
+	Exponent int // Number of digits in minor units, e.g. 2 for cents, 0 for precious metals.
 }
 
 // CurrencyFromISO4217 converts ISO4217 to matching currency.
 func CurrencyFromISO4217(code int32) (Currency, error) {
 	if _, ok := currencyCodes[code]; !ok {
-		return Currency{}, errors.New("code is not valid")
+		return Currency{}, fmt.Errorf("%w: %d", ErrUnknownCurrency, code)
 	}
 
 	return currencyCodes[code], nil
 }
 
+// CurrencyFromCode converts alphabetic ISO4217 code, like "USD", to matching currency.
+func CurrencyFromCode(code string) (Currency, error) {
+	num, ok := currencyAlphaCodes[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+	}
+
+	return currencyCodes[num], nil
+}
+
+// ISO4217 returns numeric code of the currency.
+func (ccy Currency) ISO4217() (int32, error) {
+	num, ok := currencyAlphaCodes[ccy.Code]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCurrency, ccy.Code)
+	}
+
+	return num, nil
+}
+
 // Exchange contains market buy/sell rates.
+// Rates are quoted as units of CodeB currency for one unit of CodeA currency.
 // See https://api.monobank.ua/docs/#/definitions/CurrencyInfo for details.
 type Exchange struct {
 	CodeA     int32   `json:"currencyCodeA"`
 	CodeB     int32   `json:"currencyCodeB"`
-	Date      int32   `json:"date"`
+	Date      Time    `json:"date"`
 	RateSell  float64 `json:"rateSell"`
 	RateBuy   float64 `json:"rateBuy"`
 	RateCross float64 `json:"rateCross"`
@@ -106,3 +78,65 @@ func (ex *Exchange) Base() (Currency, error) {
 func (ex *Exchange) Quote() (Currency, error) {
 	return CurrencyFromISO4217(ex.CodeB)
 }
+
+// Inverse returns rates of the reversed pair, where CodeB currency is quoted in CodeA currency.
+// Bank buys CodeB currency at inverted sell rate of the original pair and vice versa.
+func (ex *Exchange) Inverse() Exchange {
+	inverse := Exchange{
+		CodeA: ex.CodeB,
+		CodeB: ex.CodeA,
+		Date:  ex.Date,
+	}
+
+	if ex.RateSell != 0 {
+		inverse.RateBuy = 1 / ex.RateSell
+	}
+	if ex.RateBuy != 0 {
+		inverse.RateSell = 1 / ex.RateBuy
+	}
+	if ex.RateCross != 0 {
+		inverse.RateCross = 1 / ex.RateCross
+	}
+
+	return inverse
+}
+
+// Rates is a list of currency rates returned by MonoBank API.
+type Rates []Exchange
+
+// Pair returns rates of currencies specified by numeric ISO 4217 codes.
+// If only reversed pair is listed, it's inverted.
+func (rates Rates) Pair(base, quote int32) (Exchange, error) {
+	for i := range rates {
+		if rates[i].CodeA == base && rates[i].CodeB == quote {
+			return rates[i], nil
+		}
+	}
+
+	for i := range rates {
+		if rates[i].CodeA == quote && rates[i].CodeB == base {
+			return rates[i].Inverse(), nil
+		}
+	}
+
+	return Exchange{}, fmt.Errorf("%w: %d/%d", ErrNoRate, base, quote)
+}
+
+// PairByCode returns rates of currencies specified by alphabetic ISO 4217 codes, like "USD".
+func (rates Rates) PairByCode(base, quote string) (Exchange, error) {
+	baseCcy, err := CurrencyFromCode(base)
+	if err != nil {
+		return Exchange{}, err
+	}
+
+	quoteCcy, err := CurrencyFromCode(quote)
+	if err != nil {
+		return Exchange{}, err
+	}
+
+	// Codes are known, so they always have numeric representation.
+	baseNum, _ := baseCcy.ISO4217()
+	quoteNum, _ := quoteCcy.ISO4217()
+
+	return rates.Pair(baseNum, quoteNum)
+}
@@ -1,6 +1,11 @@
 package mono
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
 
 func TestCurrencyFromISO4217(t *testing.T) {
 	for code, expected := range currencyCodes {
@@ -14,3 +19,90 @@ func TestCurrencyFromISO4217(t *testing.T) {
 		}
 	}
 }
+
+func TestCurrencyFromISO4217_Unknown(t *testing.T) {
+	_, err := CurrencyFromISO4217(1)
+	if !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("expected error: %v, actual error: %v", ErrUnknownCurrency, err)
+	}
+}
+
+func TestCurrencyFromCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		number   int32
+		exponent int
+	}{
+		{"USD", 840, 2},
+		{"uah", 980, 2},
+		{"JPY", 392, 0},
+		{"KWD", 414, 3},
+		{"CNY", 156, 2},
+		{"GEL", 981, 2},
+		{"KZT", 398, 2},
+		{"AED", 784, 2},
+	}
+
+	for _, tt := range tests {
+		ccy, err := CurrencyFromCode(tt.code)
+		if err != nil {
+			t.Errorf("expected error: nil, actual error: %v", err)
+			continue
+		}
+
+		assertEqual(t, tt.exponent, ccy.Exponent)
+
+		number, err := ccy.ISO4217()
+		assertEqual(t, nil, err)
+		assertEqual(t, tt.number, number)
+	}
+
+	if _, err := CurrencyFromCode("ABC"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("expected error: %v, actual error: %v", ErrUnknownCurrency, err)
+	}
+}
+
+func TestExchange_JSON(t *testing.T) {
+	data := `{"currencyCodeA":840,"currencyCodeB":980,"date":1552392228,"rateSell":27.2,"rateBuy":27,"rateCross":0}`
+
+	var ex Exchange
+	if err := json.Unmarshal([]byte(data), &ex); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, time.Unix(1552392228, 0).UTC(), ex.Date.Time)
+
+	encoded, err := json.Marshal(ex)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, data, string(encoded))
+}
+
+func TestRates_Pair(t *testing.T) {
+	rates := Rates{
+		{CodeA: 840, CodeB: 980, RateBuy: 40, RateSell: 50},
+		{CodeA: 985, CodeB: 980, RateCross: 10},
+	}
+
+	ex, err := rates.Pair(840, 980)
+	assertEqual(t, nil, err)
+	assertEqual(t, rates[0], ex)
+
+	ex, err = rates.PairByCode("uah", "usd")
+	assertEqual(t, nil, err)
+	assertEqual(t, Exchange{CodeA: 980, CodeB: 840, RateBuy: 0.02, RateSell: 0.025}, ex)
+
+	ex, err = rates.PairByCode("UAH", "PLN")
+	assertEqual(t, nil, err)
+	assertEqual(t, 0.1, ex.RateCross)
+
+	if _, err := rates.Pair(978, 980); !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected error: %v, actual error: %v", ErrNoRate, err)
+	}
+
+	if _, err := rates.PairByCode("ABC", "UAH"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("expected error: %v, actual error: %v", ErrUnknownCurrency, err)
+	}
+}
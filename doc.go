@@ -1,11 +1,12 @@
 /*
 Package mono implements bindings to MonoBank Developer API.
 
-This package support 3 types of authentication:
+This package support 4 types of authentication:
 
 	* Public API provides access to public endpoints with rate limitations.
 	* Personal API provides access by token to your own monobank account.
 	* Corporate API provides access for enterprise partners of Monobank.
+	* Acquiring API provides access to invoices and payments of merchants.
 
 The name mono stands for "MonoBank". It is short and clear.
 */
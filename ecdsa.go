@@ -1,16 +1,21 @@
 package mono
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
 )
@@ -20,9 +25,12 @@ var (
 	secp256k1OID asn1.ObjectIdentifier = []int{1, 3, 132, 0, 10}
 )
 
+var oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
 const (
 	ecPrivateKeyBlockType = "EC PRIVATE KEY"
 	ecPrivateKeyVersion   = 1
+	publicKeyBlockType    = "PUBLIC KEY"
 )
 
 // Initializes parameters for secp256k1 elliptic curve.
@@ -37,6 +45,11 @@ func init() {
 	secp256k1.BitSize = 256
 }
 
+type publicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
 type ecPrivateKey struct {
 	Version       int
 	PrivateKey    []byte
@@ -48,6 +61,9 @@ type ecPrivateKey struct {
 type SignTool struct {
 	B2A func([]byte) string
 	A2B func(string) ([]byte, error)
+	// Deterministic enables RFC 6979 nonces, so the same message signed with the same key
+	// always produces the same signature and doesn't depend on quality of random source.
+	Deterministic bool
 }
 
 // DefaultSignTool returns new instance of SignTool SignTool with default encoding parameters.
@@ -59,7 +75,15 @@ func DefaultSignTool() *SignTool {
 }
 
 // DecodePrivateKey decodes privage key into Elliptic Curve Digital Signature Algorithm private key.
+// SEC 1 "EC PRIVATE KEY" and PKCS#8 "PRIVATE KEY" blocks are supported.
 func (t *SignTool) DecodePrivateKey(b []byte) (*ecdsa.PrivateKey, error) {
+	return t.DecodePrivateKeyWithPassword(b, nil)
+}
+
+// DecodePrivateKeyWithPassword decodes password protected private key into Elliptic Curve Digital
+// Signature Algorithm private key. In addition to blocks supported by DecodePrivateKey, it decrypts
+// "EC PRIVATE KEY" blocks with legacy PEM encryption and PKCS#8 "ENCRYPTED PRIVATE KEY" blocks.
+func (t *SignTool) DecodePrivateKeyWithPassword(b, password []byte) (*ecdsa.PrivateKey, error) {
 	var privateKeyPemBlock *pem.Block
 
 	for {
@@ -68,37 +92,155 @@ func (t *SignTool) DecodePrivateKey(b []byte) (*ecdsa.PrivateKey, error) {
 			break
 		}
 
-		if privateKeyPemBlock.Type == ecPrivateKeyBlockType {
-			ret, err := ParseCustomECPrivateKey(privateKeyPemBlock.Bytes)
+		switch privateKeyPemBlock.Type {
+		case ecPrivateKeyBlockType:
+			der := privateKeyPemBlock.Bytes
+			if x509.IsEncryptedPEMBlock(privateKeyPemBlock) {
+				if password == nil {
+					return nil, ErrEncryptedKey
+				}
+
+				var err error
+				if der, err = x509.DecryptPEMBlock(privateKeyPemBlock, password); err != nil {
+					return nil, err
+				}
+			}
+
+			return ParseCustomECPrivateKey(der)
+		case pkcs8BlockType:
+			return ParseCustomPKCS8PrivateKey(privateKeyPemBlock.Bytes)
+		case encryptedPKCS8BlockType:
+			if password == nil {
+				return nil, ErrEncryptedKey
+			}
+
+			der, err := DecryptPKCS8PrivateKey(privateKeyPemBlock.Bytes, password)
 			if err != nil {
 				return nil, err
 			}
-			return ret, err
+
+			return ParseCustomPKCS8PrivateKey(der)
 		}
 	}
 
 	return nil, fmt.Errorf("failed to find private key block")
 }
 
+// DecodePublicKey decodes PEM encoded public key into Elliptic Curve Digital Signature Algorithm public key.
+func (t *SignTool) DecodePublicKey(b []byte) (*ecdsa.PublicKey, error) {
+	var publicKeyPemBlock *pem.Block
+
+	for {
+		publicKeyPemBlock, b = pem.Decode(b)
+		if publicKeyPemBlock == nil {
+			break
+		}
+
+		if publicKeyPemBlock.Type == publicKeyBlockType {
+			return ParseCustomECPublicKey(publicKeyPemBlock.Bytes)
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find public key block")
+}
+
+// EncodePrivateKey encodes private key into PEM block with SEC 1 EC private key.
+// Keys on secp256k1 curve are encoded with it's named curve OID.
+func (t *SignTool) EncodePrivateKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := MarshalCustomECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: ecPrivateKeyBlockType, Bytes: der}), nil
+}
+
+// EncodePublicKey encodes public key into PEM block with SubjectPublicKeyInfo.
+func (t *SignTool) EncodePublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	der, err := MarshalCustomECPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: publicKeyBlockType, Bytes: der}), nil
+}
+
+// EncodePublicKeyString encodes public key into PEM block and then into string with B2A.
+// By default, it is base64 encoded PEM, which MonoBank expects on registration of corporate API provider.
+func (t *SignTool) EncodePublicKeyString(pub *ecdsa.PublicKey) (string, error) {
+	data, err := t.EncodePublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+
+	return t.B2A(data), nil
+}
+
 // Sign signs string with specified private key.
+// Signature is deterministic, if it's enabled in SignTool.
 func (t *SignTool) Sign(key *ecdsa.PrivateKey, str string) (string, error) {
+	return t.SignWith(t.Signer(key), str)
+}
+
+// SignDeterministic signs string with specified private key using nonce derived from
+// the key and the message as described in RFC 6979.
+func (t *SignTool) SignDeterministic(key *ecdsa.PrivateKey, str string) (string, error) {
+	return t.SignWith(&keySigner{key: key, deterministic: true}, str)
+}
+
+// SignWith signs string with signer, which holds ECDSA private key outside of the process
+// or in any other way. Signer must return ASN.1 encoded signature, as ecdsa.PrivateKey does.
+func (t *SignTool) SignWith(signer crypto.Signer, str string) (string, error) {
 	hash := sha256.Sum256([]byte(str))
 
-	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	sig, err := signer.Sign(rand.Reader, hash[:], crypto.SHA256)
 	if err != nil {
 		return "", err
 	}
 
-	asn1Data := []*big.Int{r, s}
+	return t.B2A(sig), nil
+}
+
+// Signer returns crypto.Signer backed by the private key.
+// Signer produces deterministic signatures, if it's enabled in SignTool.
+func (t *SignTool) Signer(key *ecdsa.PrivateKey) crypto.Signer {
+	return &keySigner{key: key, deterministic: t.Deterministic}
+}
+
+// keySigner signs digests with private key kept in memory.
+type keySigner struct {
+	key           *ecdsa.PrivateKey
+	deterministic bool
+}
 
-	bb, err := asn1.Marshal(asn1Data)
+// Public returns public key of the signer.
+func (s *keySigner) Public() crypto.PublicKey {
+	return &s.key.PublicKey
+}
+
+// Sign returns ASN.1 encoded signature of the digest.
+// Deterministic signatures use hash function from opts, SHA-256 if opts are nil.
+func (s *keySigner) Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var r, ss *big.Int
+	var err error
+
+	if s.deterministic {
+		hash := crypto.SHA256
+		if opts != nil {
+			hash = opts.HashFunc()
+		}
+		if !hash.Available() {
+			return nil, errors.New("hash function is not available")
+		}
+		r, ss, err = signDeterministic(s.key, digest, hash.New)
+	} else {
+		r, ss, err = ecdsa.Sign(random, s.key, digest)
+	}
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	ret := t.B2A(bb)
-
-	return ret, nil
+	return asn1.Marshal([]*big.Int{r, ss})
 }
 
 // VerifyBytes verifies a digital signature. Returns nil if all is well or an error indicating
@@ -144,6 +286,19 @@ func (t *SignTool) VerifyBytesN(pubkeys []*ecdsa.PublicKey, b []byte, s string)
 	return fmt.Errorf("verification failed, no keys matched")
 }
 
+// GenerateKey generates new private key on secp256k1 curve, which is used by corporate API.
+func GenerateKey(random io.Reader) (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(secp256k1, random)
+}
+
+// KeyID returns identifier of the public key, which is hex encoded SHA-1 hash of the uncompressed point.
+func KeyID(pub *ecdsa.PublicKey) string {
+	data := elliptic.Marshal(pub.Curve, pub.X, pub.Y)
+	hash := sha1.Sum(data)
+
+	return hex.EncodeToString(hash[:])
+}
+
 func namedCurveFromOID(oid asn1.ObjectIdentifier) elliptic.Curve {
 	switch {
 	case reflect.DeepEqual(oid, secp256k1OID):
@@ -154,6 +309,12 @@ func namedCurveFromOID(oid asn1.ObjectIdentifier) elliptic.Curve {
 
 // ParseCustomECPrivateKey returns Elliptic Curve Digital Signature Algorithm private key from file content.
 func ParseCustomECPrivateKey(der []byte) (key *ecdsa.PrivateKey, err error) {
+	return parseCustomECPrivateKey(nil, der)
+}
+
+// parseCustomECPrivateKey parses SEC 1 private key. Curve of the key is overridden by
+// namedCurveOID, if it's specified, as keys wrapped into PKCS#8 may omit it.
+func parseCustomECPrivateKey(namedCurveOID asn1.ObjectIdentifier, der []byte) (key *ecdsa.PrivateKey, err error) {
 	var privKey ecPrivateKey
 	if _, err := asn1.Unmarshal(der, &privKey); err != nil {
 		return nil, errors.New("x509: failed to parse EC private key: " + err.Error())
@@ -162,8 +323,15 @@ func ParseCustomECPrivateKey(der []byte) (key *ecdsa.PrivateKey, err error) {
 		return nil, fmt.Errorf("x509: unknown EC private key version %d", privKey.Version)
 	}
 
-	curve := namedCurveFromOID(privKey.NamedCurveOID)
+	if namedCurveOID == nil {
+		namedCurveOID = privKey.NamedCurveOID
+	}
+
+	curve := namedCurveFromOID(namedCurveOID)
 	if curve == nil {
+		if !isStandardCurve(namedCurveOID) {
+			return nil, fmt.Errorf("x509: %w %s", ErrUnsupportedCurve, namedCurveOID)
+		}
 		return x509.ParseECPrivateKey(der)
 	}
 
@@ -196,8 +364,91 @@ func ParseCustomECPrivateKey(der []byte) (key *ecdsa.PrivateKey, err error) {
 	return priv, nil
 }
 
+// MarshalCustomECPrivateKey returns SEC 1 DER encoded private key, including keys on secp256k1 curve.
+func MarshalCustomECPrivateKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	if key.Curve != secp256k1 {
+		return x509.MarshalECPrivateKey(key)
+	}
+
+	privateKey := make([]byte, (key.Curve.Params().N.BitLen()+7)/8)
+	d := key.D.Bytes()
+	copy(privateKey[len(privateKey)-len(d):], d)
+
+	data := elliptic.Marshal(key.Curve, key.X, key.Y)
+
+	return asn1.Marshal(ecPrivateKey{
+		Version:       ecPrivateKeyVersion,
+		PrivateKey:    privateKey,
+		NamedCurveOID: secp256k1OID,
+		PublicKey:     asn1.BitString{Bytes: data, BitLength: 8 * len(data)},
+	})
+}
+
+// MarshalCustomECPublicKey returns DER encoded SubjectPublicKeyInfo, including keys on secp256k1 curve.
+func MarshalCustomECPublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	if pub.Curve != secp256k1 {
+		return x509.MarshalPKIXPublicKey(pub)
+	}
+
+	params, err := asn1.Marshal(secp256k1OID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := elliptic.Marshal(pub.Curve, pub.X, pub.Y)
+
+	return asn1.Marshal(publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: data, BitLength: 8 * len(data)},
+	})
+}
+
+// ParseCustomECPublicKey returns Elliptic Curve Digital Signature Algorithm public key
+// from DER encoded SubjectPublicKeyInfo, including keys on secp256k1 curve.
+func ParseCustomECPublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info publicKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, errors.New("x509: failed to parse public key: " + err.Error())
+	} else if len(rest) != 0 {
+		return nil, errors.New("x509: trailing data after public key")
+	}
+
+	if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, errors.New("x509: public key is not ECDSA")
+	}
+
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &oid); err != nil {
+		return nil, errors.New("x509: failed to parse ECDSA parameters: " + err.Error())
+	}
+
+	curve := namedCurveFromOID(oid)
+	if curve == nil {
+		pub, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, errors.New("x509: public key is not ECDSA")
+		}
+		return key, nil
+	}
+
+	x, y := elliptic.Unmarshal(curve, info.PublicKey.RightAlign())
+	if x == nil {
+		return nil, errors.New("x509: failed to unmarshal elliptic curve point")
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
 // CurveParams contains the parameters of an elliptic curve and also provides
-// a generic, non-constant time implementation of Curve.
+// a constant time implementation of Curve for secp256k1.
 type CurveParams struct {
 	elliptic.CurveParams
 }
@@ -219,155 +470,50 @@ func (curve *CurveParams) IsOnCurve(x, y *big.Int) bool {
 	return x3.Cmp(y2) == 0
 }
 
-// affineFromJacobian reverses the Jacobian transform. See the comment at the
-// top of the file. If the point is ∞ it returns 0, 0.
-func (curve *CurveParams) affineFromJacobian(x, y, z *big.Int) (xOut, yOut *big.Int) {
-	if z.Sign() == 0 {
-		return new(big.Int), new(big.Int)
-	}
-
-	zinv := new(big.Int).ModInverse(z, curve.P)
-	zinvsq := new(big.Int).Mul(zinv, zinv)
-
-	xOut = new(big.Int).Mul(x, zinvsq)
-	xOut.Mod(xOut, curve.P)
-	zinvsq.Mul(zinvsq, zinv)
-	yOut = new(big.Int).Mul(y, zinvsq)
-	yOut.Mod(yOut, curve.P)
-	return
-}
-
-func (curve *CurveParams) addJacobian(
-	x1, y1, z1, x2, y2, z2 *big.Int,
-) (*big.Int, *big.Int, *big.Int) {
-	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-3.html#addition-add-2007-bl
-	x3, y3, z3 := new(big.Int), new(big.Int), new(big.Int)
-	if z1.Sign() == 0 {
-		x3.Set(x2)
-		y3.Set(y2)
-		z3.Set(z2)
-		return x3, y3, z3
-	}
-	if z2.Sign() == 0 {
-		x3.Set(x1)
-		y3.Set(y1)
-		z3.Set(z1)
-		return x3, y3, z3
-	}
+// Add returns the sum of (x1,y1) and (x2,y2).
+// Part of the elliptic.Curve interface.
+func (curve *CurveParams) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	p1, p2 := newProjectivePoint(x1, y1), newProjectivePoint(x2, y2)
 
-	z1z1 := new(big.Int).Mul(z1, z1)
-	z1z1.Mod(z1z1, curve.P)
-	z2z2 := new(big.Int).Mul(z2, z2)
-	z2z2.Mod(z2z2, curve.P)
-
-	u1 := new(big.Int).Mul(x1, z2z2)
-	u1.Mod(u1, curve.P)
-	u2 := new(big.Int).Mul(x2, z1z1)
-	u2.Mod(u2, curve.P)
-	h := new(big.Int).Sub(u2, u1)
-	xEqual := h.Sign() == 0
-	if h.Sign() == -1 {
-		h.Add(h, curve.P)
-	}
-	i := new(big.Int).Lsh(h, 1)
-	i.Mul(i, i)
-	j := new(big.Int).Mul(h, i)
-
-	s1 := new(big.Int).Mul(y1, z2)
-	s1.Mul(s1, z2z2)
-	s1.Mod(s1, curve.P)
-	s2 := new(big.Int).Mul(y2, z1)
-	s2.Mul(s2, z1z1)
-	s2.Mod(s2, curve.P)
-	r := new(big.Int).Sub(s2, s1)
-	if r.Sign() == -1 {
-		r.Add(r, curve.P)
-	}
-	yEqual := r.Sign() == 0
-	if xEqual && yEqual {
-		return curve.doubleJacobian(x1, y1, z1)
-	}
-	r.Lsh(r, 1)
-	v := new(big.Int).Mul(u1, i)
-
-	x3.Set(r)
-	x3.Mul(x3, x3)
-	x3.Sub(x3, j)
-	x3.Sub(x3, v)
-	x3.Sub(x3, v)
-	x3.Mod(x3, curve.P)
+	var r projectivePoint
+	r.add(&p1, &p2)
 
-	y3.Set(r)
-	v.Sub(v, x3)
-	y3.Mul(y3, v)
-	s1.Mul(s1, j)
-	s1.Lsh(s1, 1)
-	y3.Sub(y3, s1)
-	y3.Mod(y3, curve.P)
-
-	z3.Add(z1, z2)
-	z3.Mul(z3, z3)
-	z3.Sub(z3, z1z1)
-	z3.Sub(z3, z2z2)
-	z3.Mul(z3, h)
-	z3.Mod(z3, curve.P)
-
-	return x3, y3, z3
-}
-
-func (curve *CurveParams) doubleJacobian(x, y, z *big.Int) (*big.Int, *big.Int, *big.Int) {
-	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
-	a := new(big.Int).Mul(x, x) //X1²
-	b := new(big.Int).Mul(y, y) //Y1²
-	c := new(big.Int).Mul(b, b) //B²
-
-	d := new(big.Int).Add(x, b) //X1+B
-	d.Mul(d, d)                 //(X1+B)²
-	d.Sub(d, a)                 //(X1+B)²-A
-	d.Sub(d, c)                 //(X1+B)²-A-C
-	d.Mul(d, big.NewInt(2))     //2*((X1+B)²-A-C)
-
-	e := new(big.Int).Mul(big.NewInt(3), a) //3*A
-	f := new(big.Int).Mul(e, e)             //E²
-
-	x3 := new(big.Int).Mul(big.NewInt(2), d) //2*D
-	x3.Sub(f, x3)                            //F-2*D
-	x3.Mod(x3, curve.P)
+	return r.affine()
+}
 
-	y3 := new(big.Int).Sub(d, x3)                  //D-X3
-	y3.Mul(e, y3)                                  //E*(D-X3)
-	y3.Sub(y3, new(big.Int).Mul(big.NewInt(8), c)) //E*(D-X3)-8*C
-	y3.Mod(y3, curve.P)
+// Double returns 2*(x,y).
+// Part of the elliptic.Curve interface.
+func (curve *CurveParams) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	p := newProjectivePoint(x1, y1)
 
-	z3 := new(big.Int).Mul(y, z) //Y1*Z1
-	z3.Mul(big.NewInt(2), z3)    //3*Y1*Z1
-	z3.Mod(z3, curve.P)
+	var r projectivePoint
+	r.double(&p)
 
-	return x3, y3, z3
+	return r.affine()
 }
 
 // ScalarMult returns k*(Bx, By) where k is a big endian integer.
 // Part of the elliptic.Curve interface.
 func (curve *CurveParams) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int) {
-	Bz := new(big.Int).SetInt64(1)
-	x, y, z := new(big.Int), new(big.Int), new(big.Int)
-
-	for _, b := range k {
-		for bitNum := 0; bitNum < 8; bitNum++ {
-			x, y, z = curve.doubleJacobian(x, y, z)
-			if b&0x80 == 0x80 {
-				x, y, z = curve.addJacobian(Bx, By, Bz, x, y, z)
-			}
-			b <<= 1
-		}
-	}
+	p := newProjectivePoint(Bx, By)
+
+	var r projectivePoint
+	r.scalarMult(&p, k)
 
-	return curve.affineFromJacobian(x, y, z)
+	return r.affine()
 }
 
 // ScalarBaseMult returns k*G where G is the base point of the group and k is a
 // big endian integer.
 // Part of the elliptic.Curve interface.
 func (curve *CurveParams) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
-	return curve.ScalarMult(curve.Gx, curve.Gy, k)
+	// Precomputed tables cover scalars up to 256 bits.
+	if len(k) > 32 {
+		return curve.ScalarMult(curve.Gx, curve.Gy, k)
+	}
+
+	var r projectivePoint
+	r.scalarBaseMult(k)
+
+	return r.affine()
 }
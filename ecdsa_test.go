@@ -1 +1,385 @@
 package mono
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestGenerateKey(t *testing.T) {
+	sign := DefaultSignTool()
+
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	if key.Curve != secp256k1 || !key.Curve.IsOnCurve(key.X, key.Y) {
+		t.Fatal("expected key on secp256k1 curve")
+	}
+
+	data, err := sign.EncodePrivateKey(key)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	decoded, err := sign.DecodePrivateKey(data)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, key.D, decoded.D)
+	assertEqual(t, key.X, decoded.X)
+	assertEqual(t, key.Y, decoded.Y)
+}
+
+func TestSignTool_EncodePrivateKey(t *testing.T) {
+	sign := DefaultSignTool()
+
+	key, err := sign.DecodePrivateKey([]byte(testCorporateKey))
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	data, err := sign.EncodePrivateKey(key)
+	assertEqual(t, nil, err)
+	assertEqual(t, testCorporateKey+"\n", string(data))
+}
+
+func TestSignTool_EncodePublicKey(t *testing.T) {
+	sign := DefaultSignTool()
+
+	key, err := sign.DecodePrivateKey([]byte(testCorporateKey))
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	encoded, err := sign.EncodePublicKeyString(&key.PublicKey)
+	assertEqual(t, nil, err)
+	assertEqual(t, testCorporatePubKey, encoded)
+
+	data, err := sign.EncodePublicKey(&key.PublicKey)
+	assertEqual(t, nil, err)
+
+	pub, err := sign.DecodePublicKey(data)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+	assertEqual(t, key.PublicKey, *pub)
+
+	t.Run("standard curve", func(t *testing.T) {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+		data, err := sign.EncodePublicKey(&key.PublicKey)
+		assertEqual(t, nil, err)
+
+		pub, err := sign.DecodePublicKey(data)
+		assertEqual(t, nil, err)
+		assertEqual(t, key.X, pub.X)
+	})
+}
+
+func TestKeyID(t *testing.T) {
+	corporate := newTestCorporate(t)
+
+	// openssl ec -pubout -outform DER | tail -c 65 | sha1sum
+	assertEqual(t, "61aff8504d803dba1fe89a3a3c3ddd9e006e25fc", KeyID(corporate.auth.PublicKey))
+	assertEqual(t, "61aff8504d803dba1fe89a3a3c3ddd9e006e25fc", corporate.auth.KeyID)
+}
+
+// affineFromJacobian reverses the Jacobian transform. If the point is ∞ it returns 0, 0.
+func (curve *CurveParams) affineFromJacobian(x, y, z *big.Int) (xOut, yOut *big.Int) {
+	if z.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+
+	zinv := new(big.Int).ModInverse(z, curve.P)
+	zinvsq := new(big.Int).Mul(zinv, zinv)
+
+	xOut = new(big.Int).Mul(x, zinvsq)
+	xOut.Mod(xOut, curve.P)
+	zinvsq.Mul(zinvsq, zinv)
+	yOut = new(big.Int).Mul(y, zinvsq)
+	yOut.Mod(yOut, curve.P)
+	return
+}
+
+func (curve *CurveParams) addJacobian(
+	x1, y1, z1, x2, y2, z2 *big.Int,
+) (*big.Int, *big.Int, *big.Int) {
+	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-3.html#addition-add-2007-bl
+	x3, y3, z3 := new(big.Int), new(big.Int), new(big.Int)
+	if z1.Sign() == 0 {
+		x3.Set(x2)
+		y3.Set(y2)
+		z3.Set(z2)
+		return x3, y3, z3
+	}
+	if z2.Sign() == 0 {
+		x3.Set(x1)
+		y3.Set(y1)
+		z3.Set(z1)
+		return x3, y3, z3
+	}
+
+	z1z1 := new(big.Int).Mul(z1, z1)
+	z1z1.Mod(z1z1, curve.P)
+	z2z2 := new(big.Int).Mul(z2, z2)
+	z2z2.Mod(z2z2, curve.P)
+
+	u1 := new(big.Int).Mul(x1, z2z2)
+	u1.Mod(u1, curve.P)
+	u2 := new(big.Int).Mul(x2, z1z1)
+	u2.Mod(u2, curve.P)
+	h := new(big.Int).Sub(u2, u1)
+	xEqual := h.Sign() == 0
+	if h.Sign() == -1 {
+		h.Add(h, curve.P)
+	}
+	i := new(big.Int).Lsh(h, 1)
+	i.Mul(i, i)
+	j := new(big.Int).Mul(h, i)
+
+	s1 := new(big.Int).Mul(y1, z2)
+	s1.Mul(s1, z2z2)
+	s1.Mod(s1, curve.P)
+	s2 := new(big.Int).Mul(y2, z1)
+	s2.Mul(s2, z1z1)
+	s2.Mod(s2, curve.P)
+	r := new(big.Int).Sub(s2, s1)
+	if r.Sign() == -1 {
+		r.Add(r, curve.P)
+	}
+	yEqual := r.Sign() == 0
+	if xEqual && yEqual {
+		return curve.doubleJacobian(x1, y1, z1)
+	}
+	r.Lsh(r, 1)
+	v := new(big.Int).Mul(u1, i)
+
+	x3.Set(r)
+	x3.Mul(x3, x3)
+	x3.Sub(x3, j)
+	x3.Sub(x3, v)
+	x3.Sub(x3, v)
+	x3.Mod(x3, curve.P)
+
+	y3.Set(r)
+	v.Sub(v, x3)
+	y3.Mul(y3, v)
+	s1.Mul(s1, j)
+	s1.Lsh(s1, 1)
+	y3.Sub(y3, s1)
+	y3.Mod(y3, curve.P)
+
+	z3.Add(z1, z2)
+	z3.Mul(z3, z3)
+	z3.Sub(z3, z1z1)
+	z3.Sub(z3, z2z2)
+	z3.Mul(z3, h)
+	z3.Mod(z3, curve.P)
+
+	return x3, y3, z3
+}
+
+func (curve *CurveParams) doubleJacobian(x, y, z *big.Int) (*big.Int, *big.Int, *big.Int) {
+	// See http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
+	a := new(big.Int).Mul(x, x) //X1²
+	b := new(big.Int).Mul(y, y) //Y1²
+	c := new(big.Int).Mul(b, b) //B²
+
+	d := new(big.Int).Add(x, b) //X1+B
+	d.Mul(d, d)                 //(X1+B)²
+	d.Sub(d, a)                 //(X1+B)²-A
+	d.Sub(d, c)                 //(X1+B)²-A-C
+	d.Mul(d, big.NewInt(2))     //2*((X1+B)²-A-C)
+
+	e := new(big.Int).Mul(big.NewInt(3), a) //3*A
+	f := new(big.Int).Mul(e, e)             //E²
+
+	x3 := new(big.Int).Mul(big.NewInt(2), d) //2*D
+	x3.Sub(f, x3)                            //F-2*D
+	x3.Mod(x3, curve.P)
+
+	y3 := new(big.Int).Sub(d, x3)                  //D-X3
+	y3.Mul(e, y3)                                  //E*(D-X3)
+	y3.Sub(y3, new(big.Int).Mul(big.NewInt(8), c)) //E*(D-X3)-8*C
+	y3.Mod(y3, curve.P)
+
+	z3 := new(big.Int).Mul(y, z) //Y1*Z1
+	z3.Mul(big.NewInt(2), z3)    //3*Y1*Z1
+	z3.Mod(z3, curve.P)
+
+	return x3, y3, z3
+}
+
+// referenceScalarMult is a textbook double-and-add over big.Int, which is used to verify
+// constant time implementation of the curve.
+func (curve *CurveParams) referenceScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int) {
+	Bz := new(big.Int).SetInt64(1)
+	x, y, z := new(big.Int), new(big.Int), new(big.Int)
+
+	for _, b := range k {
+		for bitNum := 0; bitNum < 8; bitNum++ {
+			x, y, z = curve.doubleJacobian(x, y, z)
+			if b&0x80 == 0x80 {
+				x, y, z = curve.addJacobian(Bx, By, Bz, x, y, z)
+			}
+			b <<= 1
+		}
+	}
+
+	return curve.affineFromJacobian(x, y, z)
+}
+
+func assertPoint(t *testing.T, expectedX, expectedY, x, y *big.Int) {
+	if expectedX.Cmp(x) != 0 || expectedY.Cmp(y) != 0 {
+		t.Errorf("expected (%x, %x), got (%x, %x)", expectedX, expectedY, x, y)
+	}
+}
+
+// testScalars returns edge cases and random scalars for checking curve arithmetic.
+func testScalars(t testing.TB) [][]byte {
+	n := secp256k1.N
+
+	scalars := [][]byte{
+		{},
+		{0},
+		{1},
+		{2},
+		{0x10},
+		{0xff, 0xff},
+		new(big.Int).Sub(n, big.NewInt(1)).Bytes(),
+		n.Bytes(),
+		new(big.Int).Add(n, big.NewInt(1)).Bytes(),
+		make([]byte, 40),
+	}
+
+	for i := 0; i < 32; i++ {
+		k := make([]byte, 32)
+		if _, err := rand.Read(k); err != nil {
+			t.Fatal(err)
+		}
+		scalars = append(scalars, k, k[:i])
+	}
+
+	return scalars
+}
+
+func TestCurveParams_ScalarBaseMult(t *testing.T) {
+	for _, k := range testScalars(t) {
+		x, y := secp256k1.ScalarBaseMult(k)
+		expectedX, expectedY := secp256k1.referenceScalarMult(secp256k1.Gx, secp256k1.Gy, k)
+
+		assertPoint(t, expectedX, expectedY, x, y)
+	}
+}
+
+func TestCurveParams_ScalarMult(t *testing.T) {
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	for _, k := range testScalars(t) {
+		x, y := secp256k1.ScalarMult(key.X, key.Y, k)
+		expectedX, expectedY := secp256k1.referenceScalarMult(key.X, key.Y, k)
+
+		assertPoint(t, expectedX, expectedY, x, y)
+	}
+}
+
+func TestCurveParams_Add(t *testing.T) {
+	p := secp256k1.P
+	gx, gy := secp256k1.Gx, secp256k1.Gy
+
+	// G + G = 2G.
+	x, y := secp256k1.Add(gx, gy, gx, gy)
+	expectedX, expectedY := secp256k1.referenceScalarMult(gx, gy, []byte{2})
+	assertPoint(t, expectedX, expectedY, x, y)
+
+	x, y = secp256k1.Double(gx, gy)
+	assertPoint(t, expectedX, expectedY, x, y)
+
+	// G + (-G) = ∞.
+	x, y = secp256k1.Add(gx, gy, gx, new(big.Int).Sub(p, gy))
+	assertPoint(t, new(big.Int), new(big.Int), x, y)
+
+	// G + ∞ = G.
+	x, y = secp256k1.Add(gx, gy, new(big.Int), new(big.Int))
+	assertPoint(t, gx, gy, x, y)
+
+	// 2G + 3G = 5G.
+	x2, y2 := secp256k1.referenceScalarMult(gx, gy, []byte{2})
+	x3, y3 := secp256k1.referenceScalarMult(gx, gy, []byte{3})
+	x, y = secp256k1.Add(x2, y2, x3, y3)
+	expectedX, expectedY = secp256k1.referenceScalarMult(gx, gy, []byte{5})
+	assertPoint(t, expectedX, expectedY, x, y)
+}
+
+func TestSignTool_VerifyBytes(t *testing.T) {
+	sign := DefaultSignTool()
+
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	signature, err := sign.Sign(key, "message")
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	if err := sign.VerifyBytes(&key.PublicKey, []byte("message"), signature); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	if err := sign.VerifyBytes(&key.PublicKey, []byte("another message"), signature); err == nil {
+		t.Fatal("expected verification error")
+	}
+}
+
+func BenchmarkCurveParams_ScalarBaseMult(b *testing.B) {
+	k := testScalars(b)[10]
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		secp256k1.ScalarBaseMult(k)
+	}
+}
+
+func BenchmarkCurveParams_ScalarMult(b *testing.B) {
+	k := testScalars(b)[10]
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		secp256k1.ScalarMult(secp256k1.Gx, secp256k1.Gy, k)
+	}
+}
+
+func BenchmarkCurveParams_referenceScalarMult(b *testing.B) {
+	k := testScalars(b)[10]
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		secp256k1.referenceScalarMult(secp256k1.Gx, secp256k1.Gy, k)
+	}
+}
+
+func BenchmarkSignTool_Sign(b *testing.B) {
+	sign := DefaultSignTool()
+
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := sign.Sign(key, "message"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
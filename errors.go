@@ -1,10 +1,123 @@
 package mono
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrTooManyRequests is returned, when MonoBank API rate limit is exceeded.
+	ErrTooManyRequests = errors.New("too many requests")
+	// ErrUnauthorized is returned, when token, key or request ID is rejected.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound is returned, when requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidAccount is returned, when account does not exist or does not belong to the client.
+	ErrInvalidAccount = errors.New("invalid account")
+)
+
 // Error is a simple representation of MonoBank API error.
+// Acquiring API describes errors with code and text instead of description.
 type Error struct {
 	ErrorDescription string `json:"errorDescription"`
+	ErrCode          string `json:"errCode,omitempty"`
+	ErrText          string `json:"errText,omitempty"`
 }
 
 func (e Error) Error() string {
+	if e.ErrorDescription == "" {
+		return e.ErrText
+	}
+
 	return e.ErrorDescription
 }
+
+// APIError is a detailed representation of unsuccessful MonoBank API response.
+// Use errors.Is with sentinel errors to check the reason.
+type APIError struct {
+	StatusCode  int           // HTTP status code.
+	Method      string        // HTTP method of the request.
+	Endpoint    string        // Requested endpoint.
+	Body        []byte        // Raw response body.
+	Code        string        // Error code, if response body contains one.
+	Description string        // Error description, if response body contains one.
+	RetryAfter  time.Duration // Delay before the next attempt, if server specified one.
+}
+
+func newAPIError(method, endpoint string, resp *response) *APIError {
+	e := &APIError{
+		StatusCode: resp.status,
+		Method:     method,
+		Endpoint:   endpoint,
+		Body:       resp.body,
+		RetryAfter: parseRetryAfter(resp.header.Get("Retry-After")),
+	}
+
+	var msg Error
+	if err := json.Unmarshal(resp.body, &msg); err == nil {
+		e.Code = msg.ErrCode
+		e.Description = msg.Error()
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	description := e.Description
+	if description == "" {
+		description = http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.Endpoint, e.StatusCode, description)
+}
+
+// Unwrap returns sentinel error matching the response, or nil.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case e.StatusCode == http.StatusUnauthorized, e.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case strings.Contains(strings.ToLower(e.Description), "invalid account"):
+		return ErrInvalidAccount
+	}
+
+	return nil
+}
+
+// As allows to treat APIError as a simple Error for backward compatibility.
+func (e *APIError) As(target interface{}) bool {
+	if msg, ok := target.(*Error); ok {
+		*msg = Error{}
+		_ = json.Unmarshal(e.Body, msg)
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter parses value of Retry-After header in seconds or HTTP date format.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,86 @@
+package mono
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		target error
+	}{
+		{"too many requests", http.StatusTooManyRequests, `{"errorDescription":"Too many requests"}`, ErrTooManyRequests},
+		{"unauthorized", http.StatusUnauthorized, `{"errorDescription":"Unknown 'X-Token'"}`, ErrUnauthorized},
+		{"forbidden", http.StatusForbidden, `{"errorDescription":"Permissions denied"}`, ErrUnauthorized},
+		{"not found", http.StatusNotFound, `not found`, ErrNotFound},
+		{"invalid account", http.StatusBadRequest, `{"errorDescription":"invalid account"}`, ErrInvalidAccount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := FakeServer(tt.body, tt.status)
+			defer srv.Close()
+
+			personal := NewPersonal("token")
+			personal.SetBaseURL(srv.URL)
+
+			_, err := personal.User(context.Background())
+			if !errors.Is(err, tt.target) {
+				t.Errorf("expected %v, got %v", tt.target, err)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T", err)
+			}
+
+			assertEqual(t, tt.status, apiErr.StatusCode)
+			assertEqual(t, http.MethodGet, apiErr.Method)
+			assertEqual(t, "/personal/client-info", apiErr.Endpoint)
+			assertEqual(t, tt.body, string(apiErr.Body))
+		})
+	}
+}
+
+func TestAPIError_Description(t *testing.T) {
+	srv, _ := FakeServer(`{"errorDescription":"invalid account"}`, http.StatusBadRequest)
+	defer srv.Close()
+
+	core := newCore()
+	core.SetBaseURL(srv.URL)
+
+	_, err := core.Rates(context.Background())
+
+	var msg Error
+	if !errors.As(err, &msg) {
+		t.Fatalf("expected Error, got %T", err)
+	}
+	assertEqual(t, "invalid account", msg.ErrorDescription)
+}
+
+func TestAPIError_RetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Retry-After", "42")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	core := newCore()
+	core.SetBaseURL(srv.URL)
+
+	_, err := core.Rates(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	assertEqual(t, 42*time.Second, apiErr.RetryAfter)
+	assertEqual(t, "GET /bank/currency: 429 Too Many Requests", apiErr.Error())
+}
@@ -66,11 +66,17 @@ func main() {
 
 	days := make([]day, 35)
 
+	uah, err := mono.CurrencyFromISO4217(980)
+	if err != nil {
+		panic(err)
+	}
+
 	for _, t := range transactions {
-		if t.Amount < 0 {
-			days[t.Time.Day()].Expense += float64(-t.Amount/100) + float64(-t.Amount%100)
+		amount := t.Money(uah)
+		if amount.IsNegative() {
+			days[t.Time.Day()].Expense += amount.Abs().Float64()
 		} else {
-			days[t.Time.Day()].Revenue += float64(t.Amount/100) + float64(t.Amount%100)
+			days[t.Time.Day()].Revenue += amount.Float64()
 		}
 	}
 
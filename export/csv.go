@@ -0,0 +1,221 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shal/mono"
+)
+
+// Column is a column of CSV file.
+type Column string
+
+// Columns with fields of transaction, amounts are written in major units of the currency.
+const (
+	ColumnID                Column = "id"
+	ColumnTime              Column = "time"
+	ColumnDescription       Column = "description"
+	ColumnCategory          Column = "category"
+	ColumnMCC               Column = "mcc"
+	ColumnAmount            Column = "amount"
+	ColumnCurrency          Column = "currency"
+	ColumnOperationAmount   Column = "operation_amount"
+	ColumnOperationCurrency Column = "operation_currency"
+	ColumnCommission        Column = "commission"
+	ColumnCashback          Column = "cashback"
+	ColumnBalance           Column = "balance"
+	ColumnComment           Column = "comment"
+	ColumnHold              Column = "hold"
+	ColumnCounterIBAN       Column = "counter_iban"
+	ColumnCounterEDRPOU     Column = "counter_edrpou"
+)
+
+// knownColumns is a set of supported columns.
+var knownColumns = map[Column]bool{
+	ColumnID:                true,
+	ColumnTime:              true,
+	ColumnDescription:       true,
+	ColumnCategory:          true,
+	ColumnMCC:               true,
+	ColumnAmount:            true,
+	ColumnCurrency:          true,
+	ColumnOperationAmount:   true,
+	ColumnOperationCurrency: true,
+	ColumnCommission:        true,
+	ColumnCashback:          true,
+	ColumnBalance:           true,
+	ColumnComment:           true,
+	ColumnHold:              true,
+	ColumnCounterIBAN:       true,
+	ColumnCounterEDRPOU:     true,
+}
+
+// DefaultColumns are columns of CSV file, when they are not specified.
+var DefaultColumns = []Column{
+	ColumnTime,
+	ColumnDescription,
+	ColumnCategory,
+	ColumnMCC,
+	ColumnAmount,
+	ColumnCurrency,
+	ColumnOperationAmount,
+	ColumnOperationCurrency,
+	ColumnBalance,
+	ColumnID,
+}
+
+// DefaultTimeLayout is format of time in CSV file, when it's not specified.
+const DefaultTimeLayout = "2006-01-02 15:04:05"
+
+// CSV exports statements to CSV file with header.
+// Descriptions and comments are set by senders of transfers, so text starting with characters,
+// which spreadsheets treat as formula, is prefixed with apostrophe.
+type CSV struct {
+	Columns          []Column       // Columns of the file, DefaultColumns if empty.
+	Comma            rune           // Field delimiter, comma by default or semicolon when decimal separator is comma.
+	DecimalSeparator rune           // Decimal separator of amounts, dot by default.
+	TimeLayout       string         // Format of time, DefaultTimeLayout if empty.
+	Location         *time.Location // Time zone of time, UTC if nil.
+	NoHeader         bool           // Omit header with names of columns.
+	Mapping
+}
+
+// Export writes transactions of the statement to CSV file.
+func (c *CSV) Export(w io.Writer, s *Statement) error {
+	ccy, err := s.currency()
+	if err != nil {
+		return err
+	}
+
+	columns := c.Columns
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+
+	for _, column := range columns {
+		if !knownColumns[column] {
+			return fmt.Errorf("unknown column %q", column)
+		}
+	}
+
+	separator := "."
+	if c.DecimalSeparator != 0 {
+		separator = string(c.DecimalSeparator)
+	}
+
+	writer := csv.NewWriter(w)
+	switch {
+	case c.Comma != 0:
+		writer.Comma = c.Comma
+	case separator == ",":
+		writer.Comma = ';'
+	}
+
+	layout := c.TimeLayout
+	if layout == "" {
+		layout = DefaultTimeLayout
+	}
+
+	location := c.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	if !c.NoHeader {
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = string(column)
+		}
+
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(columns))
+	for i := range s.Transactions {
+		t := &s.Transactions[i]
+
+		for j, column := range columns {
+			value, err := c.value(column, t, ccy, separator, layout, location)
+			if err != nil {
+				return err
+			}
+			record[j] = value
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// value returns value of the column for transaction.
+func (c *CSV) value(
+	column Column,
+	t *mono.Transaction,
+	ccy mono.Currency,
+	separator, layout string,
+	location *time.Location,
+) (string, error) {
+	switch column {
+	case ColumnID:
+		return t.ID, nil
+	case ColumnTime:
+		return t.Time.In(location).Format(layout), nil
+	case ColumnDescription:
+		return escapeFormula(c.description(t)), nil
+	case ColumnCategory:
+		return escapeFormula(c.category(t)), nil
+	case ColumnMCC:
+		return strconv.Itoa(int(t.MCC)), nil
+	case ColumnAmount:
+		return formatAmount(t.Amount, ccy, separator), nil
+	case ColumnCurrency:
+		return ccy.Code, nil
+	case ColumnOperationAmount:
+		op, err := operationCurrency(t)
+		if err != nil {
+			return "", err
+		}
+		return formatAmount(t.OperationAmount, op, separator), nil
+	case ColumnOperationCurrency:
+		op, err := operationCurrency(t)
+		if err != nil {
+			return "", err
+		}
+		return op.Code, nil
+	case ColumnCommission:
+		return formatAmount(t.CommissionRate, ccy, separator), nil
+	case ColumnCashback:
+		return formatAmount(t.CashBackAmount, ccy, separator), nil
+	case ColumnBalance:
+		return formatAmount(t.Balance, ccy, separator), nil
+	case ColumnComment:
+		return escapeFormula(t.Comment), nil
+	case ColumnHold:
+		return strconv.FormatBool(t.Hold), nil
+	case ColumnCounterIBAN:
+		return t.IBAN, nil
+	case ColumnCounterEDRPOU:
+		return t.EDRPOU, nil
+	default:
+		return "", fmt.Errorf("unknown column %q", column)
+	}
+}
+
+// escapeFormula prefixes text with apostrophe, if it starts with character, which begins formula.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
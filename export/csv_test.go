@@ -0,0 +1,90 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+func TestCSV_Export(t *testing.T) {
+	var buf bytes.Buffer
+	if err := new(CSV).Export(&buf, testStatement()); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	expected := "" +
+		"time,description,category,mcc,amount,currency,operation_amount,operation_currency,balance,id\n" +
+		"2019-03-12 12:03:48,Silpo Kyiv,Groceries,5411,-1234.56,UAH,-1234.56,UAH,8765.44,tx1\n" +
+		"2019-03-13 12:03:48,Steam,Digital goods,5816,-275.00,UAH,-10.00,USD,8490.44,tx2\n" +
+		"2019-03-14 12:03:48,From John,Transfers,4829,0.05,UAH,0.05,UAH,8490.49,tx3\n"
+	assertEqual(t, expected, buf.String())
+}
+
+func TestCSV_ExportOptions(t *testing.T) {
+	kyiv := time.FixedZone("EET", 2*60*60)
+
+	c := &CSV{
+		Columns:          []Column{ColumnID, ColumnTime, ColumnAmount, ColumnComment, ColumnHold},
+		DecimalSeparator: ',',
+		TimeLayout:       "02.01.2006 15:04",
+		Location:         kyiv,
+	}
+
+	var buf bytes.Buffer
+	if err := c.Export(&buf, testStatement()); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	// Semicolon is used as delimiter, so decimal comma is not quoted.
+	expected := "" +
+		"id;time;amount;comment;hold\n" +
+		"tx1;12.03.2019 14:03;-1234,56;;false\n" +
+		"tx2;13.03.2019 14:03;-275,00;Game;false\n" +
+		"tx3;14.03.2019 14:03;0,05;;true\n"
+	assertEqual(t, expected, buf.String())
+
+	t.Run("no header", func(t *testing.T) {
+		c := &CSV{Columns: []Column{ColumnID}, Comma: '\t', NoHeader: true}
+
+		var buf bytes.Buffer
+		if err := c.Export(&buf, testStatement()); err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+		assertEqual(t, "tx1\ntx2\ntx3\n", buf.String())
+	})
+}
+
+func TestCSV_ExportUnknownColumn(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := (&CSV{Columns: []Column{ColumnID, "merchant"}}).Export(&buf, testStatement())
+	assertEqual(t, `unknown column "merchant"`, err.Error())
+	assertEqual(t, 0, buf.Len())
+}
+
+func TestCSV_ExportFormula(t *testing.T) {
+	s := testStatement()
+	s.Transactions = s.Transactions[:1]
+
+	c := &CSV{Columns: []Column{ColumnDescription, ColumnAmount, ColumnComment}, NoHeader: true}
+
+	for _, text := range []string{"=HYPERLINK(\"http://evil\")", "+1", "-1", "@SUM(A1)"} {
+		s.Transactions[0].Description = text
+		s.Transactions[0].Comment = text
+
+		var buf bytes.Buffer
+		if err := c.Export(&buf, s); err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+
+		r := csv.NewReader(&buf)
+		record, err := r.Read()
+		if err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+
+		// Amounts are not escaped.
+		assertEqual(t, []string{"'" + text, "-1234.56", "'" + text}, record)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Package export writes MonoBank statements in formats accepted by accounting tools.
+
+Statements can be exported to:
+
+  - CSV with configurable columns and decimal separator.
+  - OFX 2.x, transactions are identified by ID, so repeated imports are de-duplicated.
+  - QIF, which is still accepted by most personal finance managers.
+
+Amounts keep signs of MonoBank API: expenses are negative, income is positive.
+*/
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shal/mono"
+)
+
+// Statement is a list of transactions of the account.
+type Statement struct {
+	Account      mono.Account       // Account, which currency is used for amounts.
+	Transactions []mono.Transaction // Transactions in order of export.
+	From         time.Time          // Start of the period, the earliest transaction by default.
+	To           time.Time          // End of the period, the latest transaction by default.
+}
+
+// currency returns currency of the account.
+func (s *Statement) currency() (mono.Currency, error) {
+	ccy, err := mono.CurrencyFromISO4217(s.Account.CurrencyCode)
+	if err != nil {
+		return mono.Currency{}, fmt.Errorf("currency of account %s: %w", s.Account.ID, err)
+	}
+
+	return ccy, nil
+}
+
+// period returns period of the statement, falling back to times of transactions.
+func (s *Statement) period() (time.Time, time.Time) {
+	from, to := s.From, s.To
+
+	for _, t := range s.Transactions {
+		if s.From.IsZero() && (from.IsZero() || t.Time.Before(from)) {
+			from = t.Time.Time
+		}
+		if s.To.IsZero() && (to.IsZero() || t.Time.After(to)) {
+			to = t.Time.Time
+		}
+	}
+
+	return from, to
+}
+
+// Exporter writes statement in a specific format.
+type Exporter interface {
+	Export(w io.Writer, s *Statement) error
+}
+
+// Mapping describes how transactions are presented in exported files.
+// Zero value uses description of the transaction and category from MCCCategories.
+type Mapping struct {
+	Category    func(t *mono.Transaction) string // Returns category of the transaction.
+	Description func(t *mono.Transaction) string // Returns payee or description of the transaction.
+}
+
+// category returns category of the transaction.
+func (m *Mapping) category(t *mono.Transaction) string {
+	if m.Category != nil {
+		return m.Category(t)
+	}
+
+	return MCCCategory(t)
+}
+
+// description returns description of the transaction.
+func (m *Mapping) description(t *mono.Transaction) string {
+	if m.Description != nil {
+		return m.Description(t)
+	}
+
+	// Descriptions of transfers may contain line breaks.
+	return singleLine(t.Description)
+}
+
+// singleLine replaces sequences of whitespace including line breaks with single space.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// MCCCategories maps common Merchant Category Codes (ISO 18245) to categories.
+var MCCCategories = map[int32]string{
+	4111: "Transport",
+	4121: "Taxi",
+	4131: "Transport",
+	4511: "Travel",
+	4814: "Telecom",
+	4829: "Transfers",
+	4900: "Utilities",
+	5200: "Home",
+	5311: "Shopping",
+	5411: "Groceries",
+	5499: "Groceries",
+	5541: "Fuel",
+	5542: "Fuel",
+	5651: "Clothing",
+	5691: "Clothing",
+	5732: "Electronics",
+	5812: "Restaurants",
+	5813: "Bars",
+	5814: "Fast food",
+	5815: "Digital goods",
+	5816: "Digital goods",
+	5817: "Digital goods",
+	5818: "Digital goods",
+	5912: "Pharmacy",
+	5977: "Beauty",
+	6010: "Cash",
+	6011: "Cash",
+	6012: "Financial services",
+	7011: "Travel",
+	7230: "Beauty",
+	7832: "Entertainment",
+	8011: "Health",
+	8021: "Health",
+	8099: "Health",
+	8220: "Education",
+	8299: "Education",
+}
+
+// MCCCategory returns category of the transaction from MCCCategories or empty string.
+func MCCCategory(t *mono.Transaction) string {
+	return MCCCategories[t.MCC]
+}
+
+// formatAmount formats amount in minor units of the currency as decimal number without grouping.
+func formatAmount(amount int64, ccy mono.Currency, separator string) string {
+	// Absolute value is converted to unsigned, so the minimal int64 is handled correctly.
+	abs := uint64(amount)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		abs = uint64(-amount)
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+
+	exp := ccy.Exponent
+	if exp == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-exp] + separator + digits[len(digits)-exp:]
+}
+
+// operationCurrency returns currency of the transaction operation.
+func operationCurrency(t *mono.Transaction) (mono.Currency, error) {
+	ccy, err := mono.CurrencyFromISO4217(t.CurrencyCode)
+	if err != nil {
+		return mono.Currency{}, fmt.Errorf("currency of transaction %s: %w", t.ID, err)
+	}
+
+	return ccy, nil
+}
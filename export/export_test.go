@@ -0,0 +1,127 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shal/mono"
+)
+
+func assertEqual(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}
+
+// testStatement returns statement of UAH account with expense, foreign purchase and hold of income.
+func testStatement() *Statement {
+	return &Statement{
+		Account: mono.Account{
+			ID:           "acc",
+			Balance:      100000,
+			CurrencyCode: 980,
+			IBAN:         "UA213223130000026007233566001",
+		},
+		Transactions: []mono.Transaction{
+			{
+				ID:              "tx1",
+				Time:            mono.Time{Time: time.Unix(1552392228, 0).UTC()},
+				Description:     "Silpo\nKyiv",
+				MCC:             5411,
+				Amount:          -123456,
+				OperationAmount: -123456,
+				CurrencyCode:    980,
+				Balance:         876544,
+			},
+			{
+				ID:              "tx2",
+				Time:            mono.Time{Time: time.Unix(1552478628, 0).UTC()},
+				Description:     "Steam",
+				MCC:             5816,
+				Amount:          -27500,
+				OperationAmount: -1000,
+				CurrencyCode:    840,
+				Balance:         849044,
+				Comment:         "Game",
+			},
+			{
+				ID:              "tx3",
+				Time:            mono.Time{Time: time.Unix(1552565028, 0).UTC()},
+				Description:     "From John",
+				MCC:             4829,
+				Hold:            true,
+				Amount:          5,
+				OperationAmount: 5,
+				CurrencyCode:    980,
+				Balance:         849049,
+			},
+		},
+	}
+}
+
+func TestExporters(t *testing.T) {
+	for _, e := range []Exporter{new(CSV), new(OFX), new(QIF)} {
+		err := e.Export(nil, &Statement{Account: mono.Account{ID: "acc", CurrencyCode: 1}})
+		if err == nil {
+			t.Errorf("%T: expected error for unknown currency", e)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	uah, _ := mono.CurrencyFromCode("UAH")
+	jpy, _ := mono.CurrencyFromCode("JPY")
+	bhd, _ := mono.CurrencyFromCode("BHD")
+
+	tests := []struct {
+		amount   int64
+		ccy      mono.Currency
+		expected string
+	}{
+		{123456, uah, "1234,56"},
+		{-5, uah, "-0,05"},
+		{0, uah, "0,00"},
+		{-1500, jpy, "-1500"},
+		{1, bhd, "0,001"},
+		{-9223372036854775808, uah, "-92233720368547758,08"},
+	}
+
+	for _, tt := range tests {
+		assertEqual(t, tt.expected, formatAmount(tt.amount, tt.ccy, ","))
+	}
+}
+
+func TestStatement_Period(t *testing.T) {
+	s := testStatement()
+
+	from, to := s.period()
+	assertEqual(t, int64(1552392228), from.Unix())
+	assertEqual(t, int64(1552565028), to.Unix())
+
+	s.From = time.Unix(1552000000, 0)
+	from, to = s.period()
+	assertEqual(t, int64(1552000000), from.Unix())
+	assertEqual(t, int64(1552565028), to.Unix())
+}
+
+func TestMapping(t *testing.T) {
+	tx := &testStatement().Transactions[0]
+
+	var m Mapping
+	assertEqual(t, "Groceries", m.category(tx))
+	assertEqual(t, "Silpo Kyiv", m.description(tx))
+
+	m = Mapping{
+		Category: func(t *mono.Transaction) string {
+			return "Food"
+		},
+		Description: func(t *mono.Transaction) string {
+			return t.ID
+		},
+	}
+	assertEqual(t, "Food", m.category(tx))
+	assertEqual(t, "tx1", m.description(tx))
+}
@@ -0,0 +1,238 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shal/mono"
+)
+
+// DefaultBankID is MFO of JSC Universal Bank, which issues MonoBank accounts.
+const DefaultBankID = "322001"
+
+// ofxHeader is XML declaration and processing instruction of OFX 2.2 file.
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n" +
+	`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+
+// ofxTimeLayout is format of OFX date and time, time zone is appended separately.
+const ofxTimeLayout = "20060102150405.000"
+
+// ofxNameLength is the maximum length of payee name in OFX.
+const ofxNameLength = 32
+
+// now returns current time, it's replaced in tests.
+var now = time.Now
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxCurrency struct {
+	Rate   string `xml:"CURRATE"`
+	Symbol string `xml:"CURSYM"`
+}
+
+type ofxTransaction struct {
+	Type         string       `xml:"TRNTYPE"`
+	Posted       string       `xml:"DTPOSTED"`
+	Amount       string       `xml:"TRNAMT"`
+	FITID        string       `xml:"FITID"`
+	Name         string       `xml:"NAME,omitempty"`
+	Memo         string       `xml:"MEMO,omitempty"`
+	OrigCurrency *ofxCurrency `xml:"ORIGCURRENCY,omitempty"`
+}
+
+type ofxAccount struct {
+	BankID string `xml:"BANKID,omitempty"`
+	ID     string `xml:"ACCTID"`
+	Type   string `xml:"ACCTTYPE,omitempty"`
+}
+
+type ofxStatementResponse struct {
+	Currency    string      `xml:"CURDEF"`
+	BankAccount *ofxAccount `xml:"BANKACCTFROM,omitempty"`
+	CardAccount *ofxAccount `xml:"CCACCTFROM,omitempty"`
+	List        struct {
+		Start        string           `xml:"DTSTART"`
+		End          string           `xml:"DTEND"`
+		Transactions []ofxTransaction `xml:"STMTTRN"`
+	} `xml:"BANKTRANLIST"`
+	Balance struct {
+		Amount string `xml:"BALAMT"`
+		AsOf   string `xml:"DTASOF"`
+	} `xml:"LEDGERBAL"`
+}
+
+type ofxStatementTransaction struct {
+	TransactionID string                `xml:"TRNUID"`
+	Status        ofxStatus             `xml:"STATUS"`
+	Bank          *ofxStatementResponse `xml:"STMTRS,omitempty"`
+	Card          *ofxStatementResponse `xml:"CCSTMTRS,omitempty"`
+}
+
+type ofxDocument struct {
+	XMLName xml.Name `xml:"OFX"`
+	SignOn  struct {
+		Status   ofxStatus `xml:"STATUS"`
+		Server   string    `xml:"DTSERVER"`
+		Language string    `xml:"LANGUAGE"`
+	} `xml:"SIGNONMSGSRSV1>SONRS"`
+	Bank *ofxStatementTransaction `xml:"BANKMSGSRSV1>STMTTRNRS,omitempty"`
+	Card *ofxStatementTransaction `xml:"CREDITCARDMSGSRSV1>CCSTMTTRNRS,omitempty"`
+}
+
+// OFX exports statements to OFX 2.2 file with bank statement.
+// Accounts with credit limit are exported as credit card statements, like in QIF.
+// Transactions are identified by IDs from MonoBank, so repeated imports are de-duplicated.
+type OFX struct {
+	BankID string // Routing number of the bank, DefaultBankID if empty.
+	Mapping
+}
+
+// Export writes statement to OFX file.
+// Ledger balance is a balance after the latest transaction, or current balance of the account without transactions.
+func (o *OFX) Export(w io.Writer, s *Statement) error {
+	ccy, err := s.currency()
+	if err != nil {
+		return err
+	}
+
+	generated := now()
+
+	var doc ofxDocument
+	doc.SignOn.Status = ofxStatus{Code: 0, Severity: "INFO"}
+	doc.SignOn.Server = formatOFXTime(generated)
+	doc.SignOn.Language = "UKR"
+
+	st := &ofxStatementTransaction{
+		TransactionID: "0",
+		Status:        ofxStatus{Code: 0, Severity: "INFO"},
+	}
+
+	rs := &ofxStatementResponse{Currency: ccy.Code}
+
+	account := &ofxAccount{ID: s.Account.IBAN}
+	if account.ID == "" {
+		account.ID = s.Account.ID
+	}
+
+	// Credit card account is identified by number only.
+	if s.Account.CreditLimit > 0 {
+		rs.CardAccount = account
+		st.Card = rs
+		doc.Card = st
+	} else {
+		account.BankID = o.BankID
+		if account.BankID == "" {
+			account.BankID = DefaultBankID
+		}
+		account.Type = "CHECKING"
+
+		rs.BankAccount = account
+		st.Bank = rs
+		doc.Bank = st
+	}
+
+	// Empty statement without period is reported as of now.
+	from, to := s.period()
+	if from.IsZero() {
+		from = generated
+	}
+	if to.IsZero() {
+		to = generated
+	}
+	rs.List.Start = formatOFXTime(from)
+	rs.List.End = formatOFXTime(to)
+
+	balance, asOf := int64(s.Account.Balance), generated
+	var latest *mono.Transaction
+
+	rs.List.Transactions = make([]ofxTransaction, 0, len(s.Transactions))
+	for i := range s.Transactions {
+		t := &s.Transactions[i]
+
+		tx, err := o.transaction(t, s.Account.CurrencyCode, ccy)
+		if err != nil {
+			return err
+		}
+		rs.List.Transactions = append(rs.List.Transactions, tx)
+
+		if latest == nil || t.Time.After(latest.Time.Time) {
+			latest = t
+		}
+	}
+
+	if latest != nil {
+		balance, asOf = latest.Balance, latest.Time.Time
+	}
+
+	rs.Balance.Amount = formatAmount(balance, ccy, ".")
+	rs.Balance.AsOf = formatOFXTime(asOf)
+
+	if _, err := io.WriteString(w, ofxHeader); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// transaction returns OFX representation of the transaction.
+func (o *OFX) transaction(t *mono.Transaction, code int32, ccy mono.Currency) (ofxTransaction, error) {
+	tx := ofxTransaction{
+		Type:   "CREDIT",
+		Posted: formatOFXTime(t.Time.Time),
+		Amount: formatAmount(t.Amount, ccy, "."),
+		FITID:  t.ID,
+		Name:   truncate(o.description(t), ofxNameLength),
+		Memo:   t.Comment,
+	}
+
+	switch {
+	case t.MCC == 6010 || t.MCC == 6011:
+		tx.Type = "ATM"
+	case t.Amount < 0:
+		tx.Type = "DEBIT"
+	}
+
+	if t.CurrencyCode != code && t.OperationAmount != 0 {
+		op, err := operationCurrency(t)
+		if err != nil {
+			return ofxTransaction{}, err
+		}
+
+		// Rate is amount in currency of the account for one unit of operation currency.
+		rate := float64(t.Amount) / math.Pow10(ccy.Exponent) / (float64(t.OperationAmount) / math.Pow10(op.Exponent))
+		tx.OrigCurrency = &ofxCurrency{
+			Rate:   strconv.FormatFloat(math.Round(math.Abs(rate)*1e6)/1e6, 'f', -1, 64),
+			Symbol: op.Code,
+		}
+	}
+
+	return tx, nil
+}
+
+// formatOFXTime formats time in UTC.
+func formatOFXTime(t time.Time) string {
+	return t.UTC().Format(ofxTimeLayout) + "[0:GMT]"
+}
+
+// truncate shortens string to n runes.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,206 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shal/mono"
+)
+
+func withNow(t time.Time) func() {
+	previous := now
+	now = func() time.Time {
+		return t
+	}
+
+	return func() {
+		now = previous
+	}
+}
+
+func TestOFX_Export(t *testing.T) {
+	defer withNow(time.Date(2019, 3, 20, 10, 0, 0, 0, time.UTC))()
+
+	s := testStatement()
+	s.Transactions[0].Description = "Supermarket Silpo, Kyiv, Khreshchatyk street"
+
+	var buf bytes.Buffer
+	if err := new(OFX).Export(&buf, s); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <SIGNONMSGSRSV1>
+    <SONRS>
+      <STATUS>
+        <CODE>0</CODE>
+        <SEVERITY>INFO</SEVERITY>
+      </STATUS>
+      <DTSERVER>20190320100000.000[0:GMT]</DTSERVER>
+      <LANGUAGE>UKR</LANGUAGE>
+    </SONRS>
+  </SIGNONMSGSRSV1>
+  <BANKMSGSRSV1>
+    <STMTTRNRS>
+      <TRNUID>0</TRNUID>
+      <STATUS>
+        <CODE>0</CODE>
+        <SEVERITY>INFO</SEVERITY>
+      </STATUS>
+      <STMTRS>
+        <CURDEF>UAH</CURDEF>
+        <BANKACCTFROM>
+          <BANKID>322001</BANKID>
+          <ACCTID>UA213223130000026007233566001</ACCTID>
+          <ACCTTYPE>CHECKING</ACCTTYPE>
+        </BANKACCTFROM>
+        <BANKTRANLIST>
+          <DTSTART>20190312120348.000[0:GMT]</DTSTART>
+          <DTEND>20190314120348.000[0:GMT]</DTEND>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20190312120348.000[0:GMT]</DTPOSTED>
+            <TRNAMT>-1234.56</TRNAMT>
+            <FITID>tx1</FITID>
+            <NAME>Supermarket Silpo, Kyiv, Khreshc</NAME>
+          </STMTTRN>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20190313120348.000[0:GMT]</DTPOSTED>
+            <TRNAMT>-275.00</TRNAMT>
+            <FITID>tx2</FITID>
+            <NAME>Steam</NAME>
+            <MEMO>Game</MEMO>
+            <ORIGCURRENCY>
+              <CURRATE>27.5</CURRATE>
+              <CURSYM>USD</CURSYM>
+            </ORIGCURRENCY>
+          </STMTTRN>
+          <STMTTRN>
+            <TRNTYPE>CREDIT</TRNTYPE>
+            <DTPOSTED>20190314120348.000[0:GMT]</DTPOSTED>
+            <TRNAMT>0.05</TRNAMT>
+            <FITID>tx3</FITID>
+            <NAME>From John</NAME>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL>
+          <BALAMT>8490.49</BALAMT>
+          <DTASOF>20190314120348.000[0:GMT]</DTASOF>
+        </LEDGERBAL>
+      </STMTRS>
+    </STMTTRNRS>
+  </BANKMSGSRSV1>
+</OFX>
+`
+	assertEqual(t, expected, buf.String())
+}
+
+func TestOFX_ExportEmpty(t *testing.T) {
+	defer withNow(time.Date(2019, 3, 20, 10, 0, 0, 0, time.UTC))()
+
+	s := &Statement{
+		Account: mono.Account{ID: "acc", Balance: 100, CurrencyCode: 840},
+		From:    time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var buf bytes.Buffer
+	if err := (&OFX{BankID: "300001"}).Export(&buf, s); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	var doc ofxDocument
+	if err := xml.NewDecoder(strings.NewReader(buf.String())).Decode(&doc); err != nil {
+		t.Fatalf("expected valid XML, actual error: %v", err)
+	}
+
+	rs := doc.Bank.Bank
+	assertEqual(t, "USD", rs.Currency)
+	assertEqual(t, "300001", rs.BankAccount.BankID)
+	assertEqual(t, "acc", rs.BankAccount.ID)
+	assertEqual(t, "20190301000000.000[0:GMT]", rs.List.Start)
+	assertEqual(t, "20190320100000.000[0:GMT]", rs.List.End)
+	assertEqual(t, 0, len(rs.List.Transactions))
+	assertEqual(t, "1.00", rs.Balance.Amount)
+	assertEqual(t, "20190320100000.000[0:GMT]", rs.Balance.AsOf)
+}
+
+func TestOFX_ExportCreditCard(t *testing.T) {
+	defer withNow(time.Date(2019, 3, 20, 10, 0, 0, 0, time.UTC))()
+
+	s := testStatement()
+	s.Account.CreditLimit = 5000000
+	s.Transactions = s.Transactions[:1]
+
+	var buf bytes.Buffer
+	if err := new(OFX).Export(&buf, s); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <SIGNONMSGSRSV1>
+    <SONRS>
+      <STATUS>
+        <CODE>0</CODE>
+        <SEVERITY>INFO</SEVERITY>
+      </STATUS>
+      <DTSERVER>20190320100000.000[0:GMT]</DTSERVER>
+      <LANGUAGE>UKR</LANGUAGE>
+    </SONRS>
+  </SIGNONMSGSRSV1>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <TRNUID>0</TRNUID>
+      <STATUS>
+        <CODE>0</CODE>
+        <SEVERITY>INFO</SEVERITY>
+      </STATUS>
+      <CCSTMTRS>
+        <CURDEF>UAH</CURDEF>
+        <CCACCTFROM>
+          <ACCTID>UA213223130000026007233566001</ACCTID>
+        </CCACCTFROM>
+        <BANKTRANLIST>
+          <DTSTART>20190312120348.000[0:GMT]</DTSTART>
+          <DTEND>20190312120348.000[0:GMT]</DTEND>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20190312120348.000[0:GMT]</DTPOSTED>
+            <TRNAMT>-1234.56</TRNAMT>
+            <FITID>tx1</FITID>
+            <NAME>Silpo Kyiv</NAME>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL>
+          <BALAMT>8765.44</BALAMT>
+          <DTASOF>20190312120348.000[0:GMT]</DTASOF>
+        </LEDGERBAL>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+	assertEqual(t, expected, buf.String())
+}
+
+func TestOFX_ExportATM(t *testing.T) {
+	s := testStatement()
+	s.Transactions = s.Transactions[:1]
+	s.Transactions[0].MCC = 6011
+
+	var buf bytes.Buffer
+	if err := new(OFX).Export(&buf, s); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "<TRNTYPE>ATM</TRNTYPE>") {
+		t.Errorf("expected ATM transaction, actual: %s", buf.String())
+	}
+}
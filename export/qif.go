@@ -0,0 +1,70 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultQIFDateLayout is format of dates in QIF file, when it's not specified.
+const DefaultQIFDateLayout = "01/02/2006"
+
+// QIF exports statements to QIF file.
+// Format has no notion of currency, so amounts are written in currency of the account.
+// Accounts with credit limit are exported as credit card accounts.
+type QIF struct {
+	DateLayout string         // Format of dates, DefaultQIFDateLayout if empty.
+	Location   *time.Location // Time zone of dates, UTC if nil.
+	Mapping
+}
+
+// Export writes transactions of the statement to QIF file.
+// Settled transactions are marked as cleared, authorization holds are left uncleared.
+func (q *QIF) Export(w io.Writer, s *Statement) error {
+	ccy, err := s.currency()
+	if err != nil {
+		return err
+	}
+
+	layout := q.DateLayout
+	if layout == "" {
+		layout = DefaultQIFDateLayout
+	}
+
+	location := q.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	kind := "Bank"
+	if s.Account.CreditLimit > 0 {
+		kind = "CCard"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "!Type:%s\n", kind)
+
+	for i := range s.Transactions {
+		t := &s.Transactions[i]
+
+		fmt.Fprintf(bw, "D%s\n", t.Time.In(location).Format(layout))
+		fmt.Fprintf(bw, "T%s\n", formatAmount(t.Amount, ccy, "."))
+		if !t.Hold {
+			fmt.Fprintln(bw, "C*")
+		}
+		// Each field occupies a single line, including ones returned by custom mapping.
+		if payee := singleLine(q.description(t)); payee != "" {
+			fmt.Fprintf(bw, "P%s\n", payee)
+		}
+		if memo := singleLine(t.Comment); memo != "" {
+			fmt.Fprintf(bw, "M%s\n", memo)
+		}
+		if category := singleLine(q.category(t)); category != "" {
+			fmt.Fprintf(bw, "L%s\n", category)
+		}
+		fmt.Fprintln(bw, "^")
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/shal/mono"
+)
+
+func TestQIF_Export(t *testing.T) {
+	var buf bytes.Buffer
+	if err := new(QIF).Export(&buf, testStatement()); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	expected := "" +
+		"!Type:Bank\n" +
+		"D03/12/2019\nT-1234.56\nC*\nPSilpo Kyiv\nLGroceries\n^\n" +
+		"D03/13/2019\nT-275.00\nC*\nPSteam\nMGame\nLDigital goods\n^\n" +
+		"D03/14/2019\nT0.05\nPFrom John\nLTransfers\n^\n"
+	assertEqual(t, expected, buf.String())
+}
+
+func TestQIF_ExportCreditCard(t *testing.T) {
+	s := testStatement()
+	s.Account.CreditLimit = 5000000
+	s.Transactions = s.Transactions[:1]
+
+	q := &QIF{DateLayout: "2006-01-02"}
+	q.Category = func(*mono.Transaction) string { return "" }
+
+	var buf bytes.Buffer
+	if err := q.Export(&buf, s); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, "!Type:CCard\nD2019-03-12\nT-1234.56\nC*\nPSilpo Kyiv\n^\n", buf.String())
+}
+
+func TestQIF_ExportCustomMapping(t *testing.T) {
+	s := testStatement()
+	s.Transactions = s.Transactions[:1]
+
+	q := new(QIF)
+	q.Description = func(t *mono.Transaction) string { return t.Description }
+	q.Category = func(*mono.Transaction) string { return "Food\n^\nD01/01/2000" }
+
+	var buf bytes.Buffer
+	if err := q.Export(&buf, s); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, "!Type:Bank\nD03/12/2019\nT-1234.56\nC*\nPSilpo Kyiv\nLFood ^ D01/01/2000\n^\n", buf.String())
+}
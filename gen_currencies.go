@@ -0,0 +1,178 @@
+//go:build ignore
+// +build ignore
+
+// This program generates currency_iso4217.go from the official ISO 4217 list.
+// Run it with "go generate" to refresh the table of currencies.
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listURL is the official list of current currencies, maintained by SIX Financial Information.
+const listURL = "https://www.six-group.com/dam/download/financial-information/data-center/iso-currrency/lists/list-one.xml"
+
+// symbols of currencies, which are not part of ISO 4217.
+var symbols = map[string]string{
+	"AMD": "֏",
+	"AUD": "$",
+	"AZN": "₼",
+	"BGN": "лв",
+	"BRL": "R$",
+	"BYN": "Br",
+	"CAD": "$",
+	"CHF": "₣",
+	"CNY": "¥",
+	"CRC": "₡",
+	"CZK": "Kč",
+	"DKK": "Kr",
+	"EUR": "€",
+	"GBP": "£",
+	"GEL": "₾",
+	"GHS": "₵",
+	"HKD": "$",
+	"HUF": "Ft",
+	"ILS": "₪",
+	"INR": "₹",
+	"ISK": "kr",
+	"JPY": "¥",
+	"KRW": "₩",
+	"KZT": "₸",
+	"LAK": "₭",
+	"MDL": "L",
+	"MNT": "₮",
+	"MXN": "$",
+	"NGN": "₦",
+	"NOK": "kr",
+	"NZD": "$",
+	"PHP": "₱",
+	"PLN": "zł",
+	"PYG": "₲",
+	"RON": "lei",
+	"RUB": "₽",
+	"SEK": "kr",
+	"SGD": "$",
+	"THB": "฿",
+	"TRY": "₺",
+	"UAH": "₴",
+	"USD": "$",
+	"VND": "₫",
+	"ZAR": "R",
+}
+
+type entry struct {
+	Name       string `xml:"CcyNm"`
+	Code       string `xml:"Ccy"`
+	Number     string `xml:"CcyNbr"`
+	MinorUnits string `xml:"CcyMnrUnts"`
+}
+
+type list struct {
+	Entries []entry `xml:"CcyTbl>CcyNtry"`
+}
+
+type currency struct {
+	Number   int
+	Name     string
+	Code     string
+	Symbol   string
+	Exponent int
+}
+
+func open(file string) (io.ReadCloser, error) {
+	if file != "" {
+		return os.Open(file)
+	}
+
+	resp, err := http.Get(listURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: %s", listURL, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+func main() {
+	file := flag.String("file", "", "path to list-one.xml, downloaded from "+listURL+" by default")
+	output := flag.String("o", "currency_iso4217.go", "output file")
+	flag.Parse()
+
+	r, err := open(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer r.Close()
+
+	var l list
+	if err := xml.NewDecoder(r).Decode(&l); err != nil {
+		log.Fatal(err)
+	}
+
+	// Currencies are listed for each country, which uses them.
+	seen := make(map[string]bool)
+	currencies := make([]currency, 0, len(l.Entries))
+	for _, e := range l.Entries {
+		if e.Code == "" || seen[e.Code] {
+			continue
+		}
+		seen[e.Code] = true
+
+		number, err := strconv.Atoi(e.Number)
+		if err != nil {
+			log.Fatalf("invalid numeric code of %s: %v", e.Code, err)
+		}
+
+		// Minor units are "N.A." for precious metals and other special codes.
+		exponent, err := strconv.Atoi(e.MinorUnits)
+		if err != nil {
+			exponent = 0
+		}
+
+		currencies = append(currencies, currency{
+			Number:   number,
+			Name:     strings.TrimSpace(e.Name),
+			Code:     e.Code,
+			Symbol:   symbols[e.Code],
+			Exponent: exponent,
+		})
+	}
+
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i].Number < currencies[j].Number
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen_currencies.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package mono\n\n")
+	fmt.Fprintf(&buf, "var currencyCodes = map[int32]Currency{\n")
+	for _, c := range currencies {
+		fmt.Fprintf(&buf, "%d: {Name: %q, Code: %q, Symbol: %q, Exponent: %d},\n", c.Number, c.Name, c.Code, c.Symbol, c.Exponent)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,319 @@
+package mono
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PaymentType is type of acquiring payment.
+type PaymentType string
+
+const (
+	// PaymentDebit charges client immediately.
+	PaymentDebit PaymentType = "debit"
+	// PaymentHold holds amount on client's account until finalization.
+	PaymentHold PaymentType = "hold"
+)
+
+// InvoiceStatusType is a state of invoice processing.
+type InvoiceStatusType string
+
+const (
+	InvoiceCreated    InvoiceStatusType = "created"
+	InvoiceProcessing InvoiceStatusType = "processing"
+	InvoiceHold       InvoiceStatusType = "hold"
+	InvoiceSuccess    InvoiceStatusType = "success"
+	InvoiceFailure    InvoiceStatusType = "failure"
+	InvoiceReversed   InvoiceStatusType = "reversed"
+	InvoiceExpired    InvoiceStatusType = "expired"
+)
+
+// BasketItem is a product in the invoice.
+type BasketItem struct {
+	Name     string `json:"name"`           // Name of the product.
+	Qty      int    `json:"qty"`            // Quantity.
+	Sum      int64  `json:"sum"`            // Price of a single item in minimal units (cents).
+	Icon     string `json:"icon,omitempty"` // URL of the product image.
+	Unit     string `json:"unit,omitempty"` // Unit of measure.
+	Code     string `json:"code"`           // Product code.
+	Barcode  string `json:"barcode,omitempty"`
+	Header   string `json:"header,omitempty"`
+	Footer   string `json:"footer,omitempty"`
+	Tax      []int  `json:"tax,omitempty"`
+	Uktzed   string `json:"uktzed,omitempty"`
+	Discount []int  `json:"discounts,omitempty"`
+}
+
+// MerchantPaymInfo is merchant's information about the payment.
+type MerchantPaymInfo struct {
+	Reference      string       `json:"reference,omitempty"`   // Merchant's order number.
+	Destination    string       `json:"destination,omitempty"` // Purpose of the payment.
+	Comment        string       `json:"comment,omitempty"`
+	CustomerEmails []string     `json:"customerEmails,omitempty"`
+	BasketOrder    []BasketItem `json:"basketOrder,omitempty"`
+}
+
+// InvoiceRequest is a payload for creating new invoice.
+// See https://api.monobank.ua/docs/acquiring.html#/paths/~1api~1merchant~1invoice~1create/post for details.
+type InvoiceRequest struct {
+	Amount           int64             `json:"amount"`        // Amount in minimal units (cents).
+	CurrencyCode     int32             `json:"ccy,omitempty"` // Currency code in ISO4217, UAH by default.
+	MerchantPaymInfo *MerchantPaymInfo `json:"merchantPaymInfo,omitempty"`
+	RedirectURL      string            `json:"redirectUrl,omitempty"` // URL to redirect client after payment.
+	WebHookURL       string            `json:"webHookUrl,omitempty"`  // URL for receiving invoice status changes.
+	Validity         int64             `json:"validity,omitempty"`    // Invoice lifetime in seconds.
+	PaymentType      PaymentType       `json:"paymentType,omitempty"`
+}
+
+// Invoice is a created invoice.
+type Invoice struct {
+	InvoiceID string `json:"invoiceId"` // Unique invoice ID.
+	PageURL   string `json:"pageUrl"`   // URL of the payment page.
+}
+
+// PaymentInfo describes payment of the invoice.
+type PaymentInfo struct {
+	MaskedPan     string `json:"maskedPan"`
+	ApprovalCode  string `json:"approvalCode"`
+	RRN           string `json:"rrn"`
+	TranID        string `json:"tranId"`
+	Terminal      string `json:"terminal"`
+	Bank          string `json:"bank"`
+	PaymentSystem string `json:"paymentSystem"`
+	PaymentMethod string `json:"paymentMethod"`
+	Fee           int64  `json:"fee"`
+	Country       string `json:"country"`
+	AgentFee      int64  `json:"agentFee"`
+}
+
+// CancelListItem is a cancellation of the invoice payment.
+type CancelListItem struct {
+	Status       InvoiceStatusType `json:"status"`
+	Amount       int64             `json:"amount"`
+	CurrencyCode int32             `json:"ccy"`
+	CreatedDate  Time              `json:"createdDate"`
+	ModifiedDate Time              `json:"modifiedDate"`
+	ApprovalCode string            `json:"approvalCode"`
+	RRN          string            `json:"rrn"`
+	ExtRef       string            `json:"extRef"`
+}
+
+// InvoiceStatus is a current state of the invoice.
+// See https://api.monobank.ua/docs/acquiring.html#/paths/~1api~1merchant~1invoice~1status?invoiceId=%7BinvoiceId%7D/get for details.
+type InvoiceStatus struct {
+	InvoiceID     string            `json:"invoiceId"`
+	Status        InvoiceStatusType `json:"status"`
+	FailureReason string            `json:"failureReason"`
+	ErrCode       string            `json:"errCode"`
+	Amount        int64             `json:"amount"`      // Amount in minimal units (cents).
+	CurrencyCode  int32             `json:"ccy"`         // Currency code in ISO4217.
+	FinalAmount   int64             `json:"finalAmount"` // Amount after holds and cancellations.
+	CreatedDate   Time              `json:"createdDate"`
+	ModifiedDate  Time              `json:"modifiedDate"`
+	Reference     string            `json:"reference"`
+	Destination   string            `json:"destination"`
+	CancelList    []CancelListItem  `json:"cancelList"`
+	PaymentInfo   *PaymentInfo      `json:"paymentInfo"`
+}
+
+// Currency returns normal representation of CurrencyCode.
+func (s *InvoiceStatus) Currency() (Currency, error) {
+	return CurrencyFromISO4217(s.CurrencyCode)
+}
+
+// CancelRequest is a payload for cancellation of successful payment.
+type CancelRequest struct {
+	InvoiceID string       `json:"invoiceId"`
+	ExtRef    string       `json:"extRef,omitempty"` // Merchant's cancellation reference.
+	Amount    int64        `json:"amount,omitempty"` // Amount to return, full amount by default.
+	Items     []BasketItem `json:"items,omitempty"`
+}
+
+// CancelStatus is a state of the payment cancellation.
+type CancelStatus struct {
+	Status       InvoiceStatusType `json:"status"`
+	CreatedDate  Time              `json:"createdDate"`
+	ModifiedDate Time              `json:"modifiedDate"`
+}
+
+// MerchantDetails is an information about the merchant.
+type MerchantDetails struct {
+	MerchantID   string `json:"merchantId"`
+	MerchantName string `json:"merchantName"`
+	EDRPOU       string `json:"edrpou"`
+}
+
+// MerchantStatementItem is a payment in the merchant statement.
+type MerchantStatementItem struct {
+	InvoiceID     string            `json:"invoiceId"`
+	Status        InvoiceStatusType `json:"status"`
+	MaskedPan     string            `json:"maskedPan"`
+	Date          Time              `json:"date"`
+	PaymentScheme string            `json:"paymentScheme"`
+	Amount        int64             `json:"amount"`
+	ProfitAmount  int64             `json:"profitAmount"`
+	CurrencyCode  int32             `json:"ccy"`
+	ApprovalCode  string            `json:"approvalCode"`
+	RRN           string            `json:"rrn"`
+	Reference     string            `json:"reference"`
+	ShortQRID     string            `json:"shortQrId"`
+	CancelList    []CancelListItem  `json:"cancelList"`
+}
+
+// Merchant gives access to acquiring methods.
+// See https://api.monobank.ua/docs/acquiring.html for details.
+type Merchant struct {
+	authCore authCore
+}
+
+// NewMerchant returns new client of MonoBank Acquiring API.
+// Acquiring API uses the same X-Token authorization as personal API.
+func NewMerchant(token string, opts ...Option) *Merchant {
+	return &Merchant{
+		authCore: *newAuthCore(newPersonalAuth(token), token, opts...),
+	}
+}
+
+// postJSON encodes payload and makes POST request, response is decoded into v.
+func (m *Merchant) postJSON(ctx context.Context, endpoint string, payload interface{}, v interface{}) error {
+	buff, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+
+	body, err := m.authCore.call(ctx, http.MethodPost, endpoint, headers, bytes.NewReader(buff))
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// getJSON makes GET request, response is decoded into v.
+func (m *Merchant) getJSON(ctx context.Context, endpoint string, v interface{}) error {
+	body, err := m.authCore.call(ctx, http.MethodGet, endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// CreateInvoice creates new invoice for payment.
+// See https://api.monobank.ua/docs/acquiring.html#/paths/~1api~1merchant~1invoice~1create/post for details.
+func (m *Merchant) CreateInvoice(ctx context.Context, req *InvoiceRequest) (*Invoice, error) {
+	invoice := new(Invoice)
+	if err := m.postJSON(ctx, "/api/merchant/invoice/create", req, invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// InvoiceStatus returns current state of the invoice.
+// See https://api.monobank.ua/docs/acquiring.html#/paths/~1api~1merchant~1invoice~1status?invoiceId=%7BinvoiceId%7D/get for details.
+func (m *Merchant) InvoiceStatus(ctx context.Context, invoiceID string) (*InvoiceStatus, error) {
+	query := url.Values{"invoiceId": {invoiceID}}
+
+	status := new(InvoiceStatus)
+	if err := m.getJSON(ctx, "/api/merchant/invoice/status?"+query.Encode(), status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// CancelInvoice cancels successful payment fully or partially.
+// See https://api.monobank.ua/docs/acquiring.html#/paths/~1api~1merchant~1invoice~1cancel/post for details.
+func (m *Merchant) CancelInvoice(ctx context.Context, req *CancelRequest) (*CancelStatus, error) {
+	status := new(CancelStatus)
+	if err := m.postJSON(ctx, "/api/merchant/invoice/cancel", req, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// RemoveInvoice invalidates invoice, which is not paid yet.
+// See https://api.monobank.ua/docs/acquiring.html#/paths/~1api~1merchant~1invoice~1remove/post for details.
+func (m *Merchant) RemoveInvoice(ctx context.Context, invoiceID string) error {
+	payload := struct {
+		InvoiceID string `json:"invoiceId"`
+	}{invoiceID}
+
+	return m.postJSON(ctx, "/api/merchant/invoice/remove", payload, nil)
+}
+
+// Details returns information about the merchant.
+// See https://api.monobank.ua/docs/acquiring.html#/paths/~1api~1merchant~1details/get for details.
+func (m *Merchant) Details(ctx context.Context) (*MerchantDetails, error) {
+	details := new(MerchantDetails)
+	if err := m.getJSON(ctx, "/api/merchant/details", details); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// Statement returns list of payments from {from} till {to} time.
+// See https://api.monobank.ua/docs/acquiring.html#/paths/~1api~1merchant~1statement/get for details.
+func (m *Merchant) Statement(ctx context.Context, from, to time.Time) ([]MerchantStatementItem, error) {
+	query := url.Values{
+		"from": {strconv.FormatInt(from.Unix(), 10)},
+		"to":   {strconv.FormatInt(to.Unix(), 10)},
+	}
+
+	var data struct {
+		List []MerchantStatementItem `json:"list"`
+	}
+	if err := m.getJSON(ctx, "/api/merchant/statement?"+query.Encode(), &data); err != nil {
+		return nil, err
+	}
+
+	return data.List, nil
+}
+
+// PublicKey returns public key, which is used to sign acquiring WebHook requests.
+// See https://api.monobank.ua/docs/acquiring.html#/paths/~1api~1merchant~1pubkey/get for details.
+func (m *Merchant) PublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	var data struct {
+		Key string `json:"key"`
+	}
+	if err := m.getJSON(ctx, "/api/merchant/pubkey", &data); err != nil {
+		return nil, err
+	}
+
+	sign := DefaultSignTool()
+
+	pem, err := sign.A2B(data.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return sign.DecodePublicKey(pem)
+}
+
+// GetJSON builds the full endpoint path and gets the raw JSON.
+func (m *Merchant) GetJSON(ctx context.Context, endpoint string, headers map[string]string) ([]byte, int, error) {
+	return m.authCore.GetJSON(ctx, endpoint, headers)
+}
+
+// PostJSON builds the full endpoint path and gets the raw JSON.
+func (m *Merchant) PostJSON(ctx context.Context, endpoint string, headers map[string]string, payload io.Reader) ([]byte, int, error) {
+	return m.authCore.PostJSON(ctx, endpoint, headers, payload)
+}
@@ -0,0 +1,123 @@
+package mono
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMerchant_CreateInvoice(t *testing.T) {
+	srv, rr := FakeServer(`{"invoiceId":"p2_9ZgpZVsl3","pageUrl":"https://pay.mbnk.biz/p2_9ZgpZVsl3"}`, http.StatusOK)
+	defer srv.Close()
+
+	merchant := NewMerchant("token", WithBaseURL(srv.URL))
+
+	invoice, err := merchant.CreateInvoice(context.Background(), &InvoiceRequest{
+		Amount:       4200,
+		CurrencyCode: 980,
+		MerchantPaymInfo: &MerchantPaymInfo{
+			Reference:   "84d0070ee4e44667b31371d8f8813947",
+			Destination: "Покупка щастя",
+		},
+		PaymentType: PaymentDebit,
+	})
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	rr.AssertMethod(t, http.MethodPost)
+	rr.AssertHeaders(t, map[string]string{"X-Token": "token"})
+	rr.AssertBody(t, []byte(`{"amount":4200,"ccy":980,"merchantPaymInfo":{"reference":"84d0070ee4e44667b31371d8f8813947","destination":"Покупка щастя"},"paymentType":"debit"}`))
+
+	assertEqual(t, &Invoice{InvoiceID: "p2_9ZgpZVsl3", PageURL: "https://pay.mbnk.biz/p2_9ZgpZVsl3"}, invoice)
+}
+
+func TestMerchant_InvoiceStatus(t *testing.T) {
+	payload := `{
+		"invoiceId": "p2_9ZgpZVsl3",
+		"status": "success",
+		"amount": 4200,
+		"ccy": 980,
+		"finalAmount": 4200,
+		"createdDate": "2023-02-20T16:01:35Z",
+		"modifiedDate": "2023-02-20T16:02:10Z",
+		"reference": "84d0070ee4e44667b31371d8f8813947"
+	}`
+
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assertEqual(t, "/api/merchant/invoice/status", req.URL.Path)
+		query = req.URL.RawQuery
+		_, _ = rw.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	merchant := NewMerchant("token", WithBaseURL(srv.URL))
+
+	status, err := merchant.InvoiceStatus(context.Background(), "p2_9ZgpZVsl3")
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, "invoiceId=p2_9ZgpZVsl3", query)
+	assertEqual(t, InvoiceSuccess, status.Status)
+	assertEqual(t, int64(4200), status.FinalAmount)
+	assertEqual(t, Time{time.Date(2023, 2, 20, 16, 1, 35, 0, time.UTC)}, status.CreatedDate)
+
+	ccy, err := status.Currency()
+	assertEqual(t, nil, err)
+	assertEqual(t, "UAH", ccy.Code)
+}
+
+func TestMerchant_Statement(t *testing.T) {
+	payload := `{"list":[{"invoiceId":"p2_9ZgpZVsl3","status":"success","amount":4200,"ccy":980,"date":"2023-02-20T16:02:10Z"}]}`
+
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assertEqual(t, "/api/merchant/statement", req.URL.Path)
+		query = req.URL.RawQuery
+		_, _ = rw.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	merchant := NewMerchant("token", WithBaseURL(srv.URL))
+
+	items, err := merchant.Statement(context.Background(), time.Unix(1676851200, 0), time.Unix(1676937600, 0))
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, "from=1676851200&to=1676937600", query)
+	assertEqual(t, 1, len(items))
+	assertEqual(t, "p2_9ZgpZVsl3", items[0].InvoiceID)
+}
+
+func TestMerchant_RemoveInvoice(t *testing.T) {
+	srv, rr := FakeServer(`{}`, http.StatusOK)
+	defer srv.Close()
+
+	merchant := NewMerchant("token", WithBaseURL(srv.URL))
+
+	err := merchant.RemoveInvoice(context.Background(), "p2_9ZgpZVsl3")
+	assertEqual(t, nil, err)
+	rr.AssertBody(t, []byte(`{"invoiceId":"p2_9ZgpZVsl3"}`))
+}
+
+func TestMerchant_Error(t *testing.T) {
+	srv, _ := FakeServer(`{"errCode":"BAD_REQUEST","errText":"invalid 'invoiceId'"}`, http.StatusBadRequest)
+	defer srv.Close()
+
+	merchant := NewMerchant("token", WithBaseURL(srv.URL))
+
+	_, err := merchant.Details(context.Background())
+
+	var msg Error
+	if !errors.As(err, &msg) {
+		t.Fatalf("expected Error, got %T", err)
+	}
+	assertEqual(t, "BAD_REQUEST", msg.ErrCode)
+	assertEqual(t, "invalid 'invoiceId'", msg.Error())
+}
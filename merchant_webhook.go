@@ -0,0 +1,156 @@
+package mono
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MerchantKeyRefreshInterval is the minimal interval between requests of acquiring public key.
+// Refresh is triggered by requests with invalid signature, so it's limited to avoid
+// unauthenticated clients making the server call MonoBank API on each request.
+const MerchantKeyRefreshInterval = time.Minute
+
+// MerchantWebhookHandler receives invoice status changes sent by MonoBank to the WebHook URL of the invoice.
+// Requests are verified with X-Sign header against public key of acquiring API, which is cached and
+// refreshed once on verification failure in case of key rotation, but not more often than
+// MerchantKeyRefreshInterval.
+// Callbacks are invoked in separate goroutine, so MonoBank gets response as fast as possible.
+type MerchantWebhookHandler struct {
+	merchant *Merchant
+	sign     *SignTool
+
+	keyMu     sync.Mutex
+	key       *ecdsa.PublicKey
+	fetchedAt time.Time
+
+	now func() time.Time
+
+	mu            sync.RWMutex
+	invoiceStatus []func(ctx context.Context, status InvoiceStatus)
+}
+
+// NewMerchantWebhookHandler returns new handler of acquiring WebHook events.
+func NewMerchantWebhookHandler(merchant *Merchant) *MerchantWebhookHandler {
+	return &MerchantWebhookHandler{
+		merchant: merchant,
+		sign:     DefaultSignTool(),
+		now:      time.Now,
+	}
+}
+
+// OnInvoiceStatus registers callback for invoice status changes.
+func (h *MerchantWebhookHandler) OnInvoiceStatus(fn func(ctx context.Context, status InvoiceStatus)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.invoiceStatus = append(h.invoiceStatus, fn)
+}
+
+// errKeyRefreshLimited is returned, when public key was requested less than MerchantKeyRefreshInterval ago.
+var errKeyRefreshLimited = errors.New("public key was refreshed recently")
+
+// publicKey returns cached public key, fetching it if it is missing or equal to the stale one.
+// Key is not fetched again within MerchantKeyRefreshInterval after the previous attempt.
+func (h *MerchantWebhookHandler) publicKey(ctx context.Context, stale *ecdsa.PublicKey) (*ecdsa.PublicKey, error) {
+	h.keyMu.Lock()
+	defer h.keyMu.Unlock()
+
+	// Key might be already refreshed by concurrent request.
+	if h.key != nil && h.key != stale {
+		return h.key, nil
+	}
+
+	if !h.fetchedAt.IsZero() && h.now().Sub(h.fetchedAt) < MerchantKeyRefreshInterval {
+		return nil, errKeyRefreshLimited
+	}
+
+	// Failed attempts are limited as well.
+	h.fetchedAt = h.now()
+
+	key, err := h.merchant.PublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h.key = key
+	return key, nil
+}
+
+// verify checks signature of the body, refreshing public key once on failure.
+func (h *MerchantWebhookHandler) verify(ctx context.Context, body []byte, sign string) (bool, error) {
+	key, err := h.publicKey(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if h.sign.VerifyBytes(key, body, sign) == nil {
+		return true, nil
+	}
+
+	key, err = h.publicKey(ctx, key)
+	if errors.Is(err, errKeyRefreshLimited) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return h.sign.VerifyBytes(key, body, sign) == nil, nil
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *MerchantWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	sign := r.Header.Get("X-Sign")
+	if sign == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	ok, err := h.verify(r.Context(), body, sign)
+	if err != nil {
+		// Let MonoBank retry, when public key is not available.
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var status InvoiceStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	h.mu.RLock()
+	callbacks := make([]func(context.Context, InvoiceStatus), len(h.invoiceStatus))
+	copy(callbacks, h.invoiceStatus)
+	h.mu.RUnlock()
+
+	go func() {
+		for _, fn := range callbacks {
+			fn(context.Background(), status)
+		}
+	}()
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,196 @@
+package mono
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// PubKeyServer serves acquiring public keys one by one, repeating the last one.
+func PubKeyServer(t *testing.T, keys ...*ecdsa.PrivateKey) (*httptest.Server, *int) {
+	var mu sync.Mutex
+	requests := new(int)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		key := keys[len(keys)-1]
+		if *requests < len(keys) {
+			key = keys[*requests]
+		}
+		*requests++
+		mu.Unlock()
+
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"key": base64.StdEncoding.EncodeToString(data),
+		})
+	})
+
+	return httptest.NewServer(handler), requests
+}
+
+func signedRequest(t *testing.T, key *ecdsa.PrivateKey, body string) *http.Request {
+	sign, err := DefaultSignTool().Sign(key, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	r.Header.Set("X-Sign", sign)
+	return r
+}
+
+func TestMerchant_PublicKey(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	srv, _ := PubKeyServer(t, key)
+	defer srv.Close()
+
+	merchant := NewMerchant("token", WithBaseURL(srv.URL))
+
+	pub, err := merchant.PublicKey(context.Background())
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, key.PublicKey.X, pub.X)
+	assertEqual(t, key.PublicKey.Y, pub.Y)
+}
+
+func TestMerchantWebhookHandler(t *testing.T) {
+	body := `{"invoiceId":"p2_9ZgpZVsl3","status":"success","amount":4200,"ccy":980,"createdDate":"2023-02-20T16:01:35Z"}`
+
+	oldKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	newKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	t.Run("dispatches verified event", func(t *testing.T) {
+		srv, requests := PubKeyServer(t, oldKey)
+		defer srv.Close()
+
+		handler := NewMerchantWebhookHandler(NewMerchant("token", WithBaseURL(srv.URL)))
+
+		events := make(chan InvoiceStatus, 2)
+		handler.OnInvoiceStatus(func(ctx context.Context, status InvoiceStatus) {
+			events <- status
+		})
+
+		for i := 0; i < 2; i++ {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, signedRequest(t, oldKey, body))
+			assertEqual(t, http.StatusOK, rec.Code)
+
+			select {
+			case status := <-events:
+				assertEqual(t, "p2_9ZgpZVsl3", status.InvoiceID)
+				assertEqual(t, InvoiceSuccess, status.Status)
+			case <-time.After(time.Second):
+				t.Fatal("callback was not called")
+			}
+		}
+
+		// Public key is cached.
+		assertEqual(t, 1, *requests)
+	})
+
+	t.Run("refreshes rotated key", func(t *testing.T) {
+		srv, requests := PubKeyServer(t, oldKey, newKey)
+		defer srv.Close()
+
+		handler := NewMerchantWebhookHandler(NewMerchant("token", WithBaseURL(srv.URL)))
+
+		now := time.Now()
+		handler.now = func() time.Time { return now }
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, signedRequest(t, oldKey, body))
+		assertEqual(t, http.StatusOK, rec.Code)
+
+		now = now.Add(MerchantKeyRefreshInterval)
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, signedRequest(t, newKey, body))
+
+		assertEqual(t, http.StatusOK, rec.Code)
+		assertEqual(t, 2, *requests)
+	})
+
+	t.Run("limits refreshes of key", func(t *testing.T) {
+		srv, requests := PubKeyServer(t, oldKey, newKey)
+		defer srv.Close()
+
+		handler := NewMerchantWebhookHandler(NewMerchant("token", WithBaseURL(srv.URL)))
+
+		now := time.Now()
+		handler.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, signedRequest(t, newKey, body))
+			assertEqual(t, http.StatusUnauthorized, rec.Code)
+		}
+
+		// Key was fetched just now, so it's not refreshed.
+		assertEqual(t, 1, *requests)
+
+		now = now.Add(MerchantKeyRefreshInterval)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, signedRequest(t, newKey, body))
+		assertEqual(t, http.StatusOK, rec.Code)
+		assertEqual(t, 2, *requests)
+	})
+
+	t.Run("rejects invalid signature", func(t *testing.T) {
+		srv, requests := PubKeyServer(t, oldKey)
+		defer srv.Close()
+
+		handler := NewMerchantWebhookHandler(NewMerchant("token", WithBaseURL(srv.URL)))
+
+		called := make(chan struct{}, 1)
+		handler.OnInvoiceStatus(func(ctx context.Context, status InvoiceStatus) {
+			called <- struct{}{}
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, signedRequest(t, newKey, body))
+		assertEqual(t, http.StatusUnauthorized, rec.Code)
+		assertEqual(t, 1, *requests)
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body)))
+		assertEqual(t, http.StatusUnauthorized, rec.Code)
+
+		select {
+		case <-called:
+			t.Error("callback must not be called")
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+
+	t.Run("fails when key is not available", func(t *testing.T) {
+		srv, _ := FakeServer(`{"errCode":"INTERNAL_ERROR","errText":"internal error"}`, http.StatusInternalServerError)
+		defer srv.Close()
+
+		handler := NewMerchantWebhookHandler(NewMerchant("token", WithBaseURL(srv.URL)))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, signedRequest(t, oldKey, body))
+		assertEqual(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
@@ -0,0 +1,255 @@
+package mono
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned by operations on amounts in different currencies.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Money is an amount of money in minor units of the currency (cents, kopecks).
+type Money struct {
+	Amount   int64    // Amount in minor units.
+	Currency Currency // Currency of the amount.
+}
+
+// NewMoney returns amount of money in minor units of the currency.
+func NewMoney(amount int64, ccy Currency) Money {
+	return Money{Amount: amount, Currency: ccy}
+}
+
+// ParseMoney parses amount in major units of the currency, like "1 234,56" or "-1234.56 ₴".
+// Both dot and comma are accepted as decimal separator, spaces are accepted as thousands separator.
+// Symbol or code of the currency may follow the amount.
+func ParseMoney(s string, ccy Currency) (Money, error) {
+	str := strings.TrimSpace(s)
+	for _, suffix := range []string{ccy.Symbol, ccy.Code} {
+		if suffix != "" && strings.HasSuffix(str, suffix) {
+			str = strings.TrimSpace(strings.TrimSuffix(str, suffix))
+			break
+		}
+	}
+
+	str = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\u00a0' || r == '\u202f' {
+			return -1
+		}
+		return r
+	}, str)
+
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "-"), "+")
+
+	whole, fraction := str, ""
+	if i := strings.IndexAny(str, ".,"); i >= 0 {
+		whole, fraction = str[:i], str[i+1:]
+	}
+
+	if whole == "" && fraction == "" || len(fraction) > ccy.Exponent || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("invalid amount %q in %s", s, ccy.Code)
+	}
+
+	digits := whole + fraction + strings.Repeat("0", ccy.Exponent-len(fraction))
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q in %s: %w", s, ccy.Code, err)
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return NewMoney(amount, ccy), nil
+}
+
+// isDigits reports whether string consists of decimal digits only.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// check returns error, if amounts are in different currencies.
+func (m Money) check(other Money) error {
+	if m.Currency.Code != other.Currency.Code {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency.Code, other.Currency.Code)
+	}
+
+	return nil
+}
+
+// Add returns sum of amounts in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.check(other); err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(m.Amount+other.Amount, m.Currency), nil
+}
+
+// Sub returns difference of amounts in the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.check(other); err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(m.Amount-other.Amount, m.Currency), nil
+}
+
+// Neg returns amount with opposite sign.
+func (m Money) Neg() Money {
+	return NewMoney(-m.Amount, m.Currency)
+}
+
+// Abs returns absolute value of the amount.
+func (m Money) Abs() Money {
+	if m.Amount < 0 {
+		return m.Neg()
+	}
+
+	return m
+}
+
+// Compare returns -1, 0 or +1, if amount is less, equal or greater than other amount in the same currency.
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.check(other); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// IsZero reports whether amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative reports whether amount is less than zero.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Float64 returns approximate amount in major units, it should be used only for presentation.
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / math.Pow10(m.Currency.Exponent)
+}
+
+// String returns amount in major units with symbol of the currency, like "1 234,56 ₴".
+func (m Money) String() string {
+	var b strings.Builder
+
+	// Absolute value is converted to unsigned, so the minimal int64 is handled correctly.
+	abs := uint64(m.Amount)
+	if m.Amount < 0 {
+		b.WriteByte('-')
+		abs = uint64(-m.Amount)
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+
+	exp := m.Currency.Exponent
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+
+	whole, fraction := digits[:len(digits)-exp], digits[len(digits)-exp:]
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+
+	if fraction != "" {
+		b.WriteByte(',')
+		b.WriteString(fraction)
+	}
+
+	symbol := m.Currency.Symbol
+	if symbol == "" {
+		symbol = m.Currency.Code
+	}
+
+	if symbol != "" {
+		b.WriteByte(' ')
+		b.WriteString(symbol)
+	}
+
+	return b.String()
+}
+
+// Money returns amount of the transaction in currency of the account.
+// Transaction doesn't contain currency of the account, so it should be specified explicitly.
+func (t *Transaction) Money(account Currency) Money {
+	return NewMoney(t.Amount, account)
+}
+
+// OperationMoney returns amount of the transaction in currency of the operation.
+func (t *Transaction) OperationMoney() (Money, error) {
+	ccy, err := CurrencyFromISO4217(t.CurrencyCode)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(t.OperationAmount, ccy), nil
+}
+
+// Currency returns currency of the account.
+func (acc *Account) Currency() (Currency, error) {
+	return CurrencyFromISO4217(acc.CurrencyCode)
+}
+
+// Available returns balance of the account available for spending, including credit limit.
+func (acc *Account) Available() (Money, error) {
+	ccy, err := acc.Currency()
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(int64(acc.Balance), ccy), nil
+}
+
+// Own returns own funds of the account, which is balance without credit limit.
+func (acc *Account) Own() (Money, error) {
+	ccy, err := acc.Currency()
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(int64(acc.Balance-acc.CreditLimit), ccy), nil
+}
+
+// Saved returns balance of the jar.
+func (jar *Jar) Saved() (Money, error) {
+	ccy, err := CurrencyFromISO4217(int32(jar.CurrencyCode))
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(jar.Balance, ccy), nil
+}
+
+// Target returns goal of the jar.
+func (jar *Jar) Target() (Money, error) {
+	ccy, err := CurrencyFromISO4217(int32(jar.CurrencyCode))
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(jar.Goal, ccy), nil
+}
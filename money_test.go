@@ -0,0 +1,107 @@
+package mono
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+var (
+	testUAH = currencyCodes[980]
+	testUSD = currencyCodes[840]
+)
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		money    Money
+		expected string
+	}{
+		{NewMoney(123456, testUAH), "1 234,56 ₴"},
+		{NewMoney(-123456, testUAH), "-1 234,56 ₴"},
+		{NewMoney(5, testUAH), "0,05 ₴"},
+		{NewMoney(0, testUSD), "0,00 $"},
+		{NewMoney(100000000, testUSD), "1 000 000,00 $"},
+		{NewMoney(1234, Currency{Code: "JPY", Symbol: "¥"}), "1 234 ¥"},
+		{NewMoney(1234, Currency{Code: "XXX", Exponent: 2}), "12,34 XXX"},
+		{NewMoney(math.MinInt64, Currency{Code: "XXX"}), "-9 223 372 036 854 775 808 XXX"},
+	}
+
+	for _, tt := range tests {
+		assertEqual(t, tt.expected, tt.money.String())
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected int64
+	}{
+		{"1 234,56 ₴", 123456},
+		{"-1234.56", -123456},
+		{"12,5", 1250},
+		{"+12", 1200},
+		{".5 UAH", 50},
+		{"1 000", 100000},
+	}
+
+	for _, tt := range tests {
+		money, err := ParseMoney(tt.str, testUAH)
+		if err != nil {
+			t.Errorf("expected error: nil, actual error: %v", err)
+			continue
+		}
+
+		assertEqual(t, NewMoney(tt.expected, testUAH), money)
+	}
+
+	for _, str := range []string{"", "-", "1,234", "12a", "1.2.3", "99999999999999999999"} {
+		if _, err := ParseMoney(str, testUAH); err == nil {
+			t.Errorf("expected error for %q", str)
+		}
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a, b := NewMoney(1000, testUAH), NewMoney(250, testUAH)
+
+	sum, err := a.Add(b)
+	assertEqual(t, nil, err)
+	assertEqual(t, NewMoney(1250, testUAH), sum)
+
+	diff, err := b.Sub(a)
+	assertEqual(t, nil, err)
+	assertEqual(t, NewMoney(-750, testUAH), diff)
+	assertEqual(t, NewMoney(750, testUAH), diff.Abs())
+	assertEqual(t, NewMoney(750, testUAH), diff.Neg())
+
+	cmp, err := a.Compare(b)
+	assertEqual(t, nil, err)
+	assertEqual(t, 1, cmp)
+
+	usd := NewMoney(100, testUSD)
+	if _, err := a.Add(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected error: %v, actual error: %v", ErrCurrencyMismatch, err)
+	}
+	if _, err := a.Compare(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected error: %v, actual error: %v", ErrCurrencyMismatch, err)
+	}
+}
+
+func TestAccount_Available(t *testing.T) {
+	acc := Account{Balance: 150000, CreditLimit: 100000, CurrencyCode: 980}
+
+	available, err := acc.Available()
+	assertEqual(t, nil, err)
+	assertEqual(t, NewMoney(150000, testUAH), available)
+
+	own, err := acc.Own()
+	assertEqual(t, nil, err)
+	assertEqual(t, NewMoney(50000, testUAH), own)
+
+	tx := Transaction{Amount: -2700, OperationAmount: -100, CurrencyCode: 840}
+	assertEqual(t, NewMoney(-2700, testUAH), tx.Money(testUAH))
+
+	operation, err := tx.OperationMoney()
+	assertEqual(t, nil, err)
+	assertEqual(t, NewMoney(-100, testUSD), operation)
+}
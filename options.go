@@ -0,0 +1,81 @@
+package mono
+
+import (
+	"net/http"
+	"time"
+)
+
+// Logger is an interface for logging requests made by the client.
+// It is satisfied by *log.Logger from standard library.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Option configures MonoBank API client.
+type Option func(*core)
+
+// WithHTTPClient replaces HTTP client used to make requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *core) {
+		if client != nil {
+			c.Client = *client
+		}
+	}
+}
+
+// WithTransport replaces transport of HTTP client, e.g. to use proxy or custom TLS configuration.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *core) {
+		c.Transport = transport
+	}
+}
+
+// WithTimeout sets time limit for requests made by HTTP client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *core) {
+		c.Timeout = timeout
+	}
+}
+
+// WithBaseURL sets base URL of MonoBank API.
+func WithBaseURL(url string) Option {
+	return func(c *core) {
+		c.baseURL = url
+	}
+}
+
+// WithUserAgent sets User-Agent header of all requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *core) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithLogger sets logger of requests and retries.
+func WithLogger(logger Logger) Option {
+	return func(c *core) {
+		c.logger = logger
+	}
+}
+
+// WithRateLimiter sets rate limiter of the client, requests are not limited by default.
+// Limiter can be shared between clients, use DefaultRateLimiter to share it within the process.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *core) {
+		c.limiter = limiter
+	}
+}
+
+// WithRetryPolicy sets policy of retrying failed requests.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *core) {
+		c.retry = policy
+	}
+}
+
+// WithRatesCache enables cache of currency rates, which can be shared between clients.
+func WithRatesCache(cache *RatesCache) Option {
+	return func(c *core) {
+		c.rates = cache
+	}
+}
@@ -0,0 +1,82 @@
+package mono
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type RoundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f RoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestOptions(t *testing.T) {
+	srv, rr := FakeServer("[]", http.StatusOK)
+	defer srv.Close()
+
+	t.Run("base URL and user agent", func(t *testing.T) {
+		public := NewPublic(WithBaseURL(srv.URL), WithUserAgent("mono-test"))
+
+		_, err := public.Rates(context.Background())
+		assertEqual(t, nil, err)
+		rr.AssertHeaders(t, map[string]string{"User-Agent": "mono-test"})
+	})
+
+	t.Run("logger", func(t *testing.T) {
+		var buff bytes.Buffer
+		personal := NewPersonal("token", WithBaseURL(srv.URL), WithLogger(log.New(&buff, "", 0)))
+
+		_, _, err := personal.GetJSON(context.Background(), "/personal/client-info", nil)
+		assertEqual(t, nil, err)
+
+		if !strings.HasPrefix(buff.String(), "mono: GET /personal/client-info: 200") {
+			t.Errorf("unexpected log: %q", buff.String())
+		}
+		if strings.Contains(buff.String(), "token") {
+			t.Error("token must not be logged")
+		}
+	})
+
+	t.Run("transport", func(t *testing.T) {
+		transport := RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+			assertEqual(t, "https://example.com/bank/currency", r.URL.String())
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       ioutil.NopCloser(strings.NewReader("[]")),
+			}, nil
+		})
+
+		public := NewPublic(WithBaseURL("https://example.com"), WithTransport(transport))
+
+		_, err := public.Rates(context.Background())
+		assertEqual(t, nil, err)
+	})
+
+	t.Run("HTTP client and timeout", func(t *testing.T) {
+		client := &http.Client{Timeout: time.Minute}
+
+		public := NewPublic(WithHTTPClient(client))
+		assertEqual(t, time.Minute, public.Timeout)
+
+		public = NewPublic(WithHTTPClient(client), WithTimeout(time.Second))
+		assertEqual(t, time.Second, public.Timeout)
+	})
+
+	t.Run("rate limiter and retry policy", func(t *testing.T) {
+		limiter := NewRateLimiter(DefaultRateLimits())
+		policy := DefaultRetryPolicy()
+
+		personal := NewPersonal("token", WithRateLimiter(limiter), WithRetryPolicy(policy))
+		assertEqual(t, limiter, personal.limiter)
+		assertEqual(t, policy, personal.retry)
+	})
+}
@@ -25,9 +25,9 @@ type Personal struct {
 }
 
 // NewPersonal returns new client of MonoBank Personal API.
-func NewPersonal(token string) *Personal {
+func NewPersonal(token string, opts ...Option) *Personal {
 	return &Personal{
-		authCore: *newAuthCore(newPersonalAuth(token)),
+		authCore: *newAuthCore(newPersonalAuth(token), token, opts...),
 	}
 }
 
@@ -43,6 +43,16 @@ func (p *Personal) Transactions(ctx context.Context, account string, from, to ti
 	return p.authCore.Transactions(ctx, account, from, to, nil)
 }
 
+// AllTransactions returns complete list of transactions from {from} till {to} time.
+// Unlike Transactions, range is not limited by MonoBank API and result is sorted chronologically.
+// Range longer than MaxStatementPeriod takes several requests, which are rejected by MonoBank more often than
+// once per minute. Rejected requests are repeated after delay, rate limiter avoids rejections at all.
+func (p *Personal) AllTransactions(ctx context.Context, account string, from, to time.Time) ([]Transaction, error) {
+	return allTransactions(ctx, from, to, func(ctx context.Context, from, to time.Time) ([]Transaction, error) {
+		return p.Transactions(ctx, account, from, to)
+	})
+}
+
 // SetWebHook sets WebHook URL for authorized user.
 // See https://api.monobank.ua/docs#operation--personal-webhook-post for details.
 func (p *Personal) SetWebHook(ctx context.Context, url string) ([]byte, error) {
@@ -51,6 +61,6 @@ func (p *Personal) SetWebHook(ctx context.Context, url string) ([]byte, error) {
 
 // Rates returns list of currencies rates from MonoBank API.
 // See https://api.monobank.ua/docs/#/definitions/CurrencyInfo for details.
-func (p *Personal) Rates(ctx context.Context) ([]Exchange, error) {
+func (p *Personal) Rates(ctx context.Context) (Rates, error) {
 	return p.authCore.Rates(ctx)
 }
@@ -0,0 +1,241 @@
+package mono
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+var (
+	// ErrUnsupportedCurve is returned for private keys on elliptic curves, which are not supported.
+	ErrUnsupportedCurve = errors.New("unsupported elliptic curve")
+	// ErrEncryptedKey is returned for password protected private keys, when password is not provided.
+	ErrEncryptedKey = errors.New("private key is encrypted")
+)
+
+// maxPBKDF2Iterations limits work of key derivation for crafted keys to about a second of CPU.
+// OpenSSL uses 2048 iterations by default, OWASP recommends 600000 for PBKDF2-HMAC-SHA256.
+const maxPBKDF2Iterations = 1000000
+
+const (
+	pkcs8BlockType          = "PRIVATE KEY"
+	encryptedPKCS8BlockType = "ENCRYPTED PRIVATE KEY"
+)
+
+var (
+	oidPBES2      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// Named curves supported by crypto/x509.
+var (
+	oidNamedCurveP224 = asn1.ObjectIdentifier{1, 3, 132, 0, 33}
+	oidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+	oidNamedCurveP521 = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
+)
+
+// pkcs8 is PrivateKeyInfo structure, see RFC 5208.
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+	// Optional attributes are omitted.
+}
+
+// encryptedPKCS8 is EncryptedPrivateKeyInfo structure, see RFC 5208.
+type encryptedPKCS8 struct {
+	Algo          pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params are parameters of PBES2 encryption scheme, see RFC 8018.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params are parameters of PBKDF2 key derivation function, see RFC 8018.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// isStandardCurve reports whether named curve is supported by crypto/x509.
+func isStandardCurve(oid asn1.ObjectIdentifier) bool {
+	return oid.Equal(oidNamedCurveP224) || oid.Equal(oidNamedCurveP256) ||
+		oid.Equal(oidNamedCurveP384) || oid.Equal(oidNamedCurveP521)
+}
+
+// ParseCustomPKCS8PrivateKey returns Elliptic Curve Digital Signature Algorithm private key
+// from DER encoded PKCS#8 PrivateKeyInfo, including keys on secp256k1 curve.
+func ParseCustomPKCS8PrivateKey(der []byte) (*ecdsa.PrivateKey, error) {
+	var privKey pkcs8
+	if _, err := asn1.Unmarshal(der, &privKey); err != nil {
+		return nil, errors.New("x509: failed to parse PKCS#8 private key: " + err.Error())
+	}
+
+	if !privKey.Algo.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, fmt.Errorf("x509: PKCS#8 private key is not ECDSA, algorithm %s", privKey.Algo.Algorithm)
+	}
+
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(privKey.Algo.Parameters.FullBytes, &oid); err != nil {
+		return nil, errors.New("x509: failed to parse ECDSA parameters: " + err.Error())
+	}
+
+	if namedCurveFromOID(oid) == nil {
+		if !isStandardCurve(oid) {
+			return nil, fmt.Errorf("x509: %w %s", ErrUnsupportedCurve, oid)
+		}
+
+		key, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, err
+		}
+
+		return key.(*ecdsa.PrivateKey), nil
+	}
+
+	return parseCustomECPrivateKey(oid, privKey.PrivateKey)
+}
+
+// DecryptPKCS8PrivateKey decrypts DER encoded PKCS#8 EncryptedPrivateKeyInfo with password
+// and returns DER encoded PrivateKeyInfo. Only PBES2 scheme with PBKDF2 and AES-CBC is supported,
+// which is default for OpenSSL.
+func DecryptPKCS8PrivateKey(der, password []byte) ([]byte, error) {
+	var encrypted encryptedPKCS8
+	if _, err := asn1.Unmarshal(der, &encrypted); err != nil {
+		return nil, errors.New("x509: failed to parse encrypted PKCS#8 private key: " + err.Error())
+	}
+
+	if !encrypted.Algo.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("x509: unsupported encryption scheme %s", encrypted.Algo.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(encrypted.Algo.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.New("x509: failed to parse PBES2 parameters: " + err.Error())
+	}
+
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("x509: unsupported key derivation function %s", params.KeyDerivationFunc.Algorithm)
+	}
+
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, errors.New("x509: failed to parse PBKDF2 parameters: " + err.Error())
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case kdf.PRF.Algorithm == nil, kdf.PRF.Algorithm.Equal(oidHMACSHA1):
+		prf = sha1.New
+	case kdf.PRF.Algorithm.Equal(oidHMACSHA256):
+		prf = sha256.New
+	default:
+		return nil, fmt.Errorf("x509: unsupported PBKDF2 pseudorandom function %s", kdf.PRF.Algorithm)
+	}
+
+	var keyLen int
+	switch scheme := params.EncryptionScheme.Algorithm; {
+	case scheme.Equal(oidAES128CBC):
+		keyLen = 16
+	case scheme.Equal(oidAES192CBC):
+		keyLen = 24
+	case scheme.Equal(oidAES256CBC):
+		keyLen = 32
+	default:
+		return nil, fmt.Errorf("x509: unsupported encryption algorithm %s", scheme)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, errors.New("x509: failed to parse initialization vector: " + err.Error())
+	}
+
+	if len(iv) != aes.BlockSize {
+		return nil, errors.New("x509: invalid initialization vector")
+	}
+
+	if kdf.IterationCount <= 0 {
+		return nil, errors.New("x509: invalid PBKDF2 iteration count")
+	}
+
+	if kdf.IterationCount > maxPBKDF2Iterations {
+		return nil, fmt.Errorf("x509: PBKDF2 iteration count %d exceeds %d", kdf.IterationCount, maxPBKDF2Iterations)
+	}
+
+	data := encrypted.EncryptedData
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, errors.New("x509: encrypted PEM data is not a multiple of the block size")
+	}
+
+	key := pbkdf2(password, kdf.Salt, kdf.IterationCount, keyLen, prf)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	// Wrong password almost always results in invalid padding.
+	n := int(plain[len(plain)-1])
+	if n == 0 || n > aes.BlockSize || !bytes.Equal(plain[len(plain)-n:], bytes.Repeat([]byte{byte(n)}, n)) {
+		return nil, x509.IncorrectPasswordError
+	}
+
+	return plain[:len(plain)-n], nil
+}
+
+// pbkdf2 derives key from password as described in section 5.2 of RFC 8018.
+func pbkdf2(password, salt []byte, iter, keyLen int, h func() hash.Hash) []byte {
+	prf := hmac.New(h, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	var buf [4]byte
+	dk := make([]byte, 0, numBlocks*hashLen)
+	u := make([]byte, hashLen)
+	for block := 1; block <= numBlocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(buf[:], uint32(block))
+		prf.Write(buf[:4])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = u[:0]
+			u = prf.Sum(u)
+			for x := range u {
+				t[x] ^= u[x]
+			}
+		}
+	}
+
+	return dk[:keyLen]
+}
@@ -6,8 +6,8 @@ type Public struct {
 }
 
 // NewPublic returns new client of MonoBank Public API.
-func NewPublic() *Public {
+func NewPublic(opts ...Option) *Public {
 	return &Public{
-		core: *newCore(),
+		core: *newCore(opts...),
 	}
 }
@@ -0,0 +1,187 @@
+package mono
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRecordInterval is interval between requests of rates by RateRecorder.
+	DefaultRecordInterval = DefaultRatesTTL
+	// MinRecordInterval is the shortest interval allowed by rate limits of MonoBank API.
+	MinRecordInterval = time.Minute
+)
+
+// RatesSource is a client, which returns current currency rates, like Public or Personal.
+type RatesSource interface {
+	Rates(ctx context.Context) (Rates, error)
+}
+
+// RateRecorder periodically requests currency rates and saves them into the store,
+// building history, which MonoBank API doesn't provide.
+// Rates are saved only when their date changes, so unchanged snapshots are not duplicated.
+type RateRecorder struct {
+	source   RatesSource
+	store    RateStore
+	interval time.Duration
+	onError  func(error)
+
+	mu   sync.Mutex
+	last map[[2]int32]time.Time
+}
+
+// NewRateRecorder returns recorder, which requests rates from source every interval.
+// Interval is DefaultRecordInterval, when it's zero, and not less than MinRecordInterval.
+func NewRateRecorder(source RatesSource, store RateStore, interval time.Duration) *RateRecorder {
+	if interval == 0 {
+		interval = DefaultRecordInterval
+	} else if interval < MinRecordInterval {
+		interval = MinRecordInterval
+	}
+
+	return &RateRecorder{
+		source:   source,
+		store:    store,
+		interval: interval,
+	}
+}
+
+// OnError sets callback, which is called on failures of background recording in Run.
+func (r *RateRecorder) OnError(fn func(error)) {
+	r.onError = fn
+}
+
+// Record requests current rates and saves ones, which changed since the last record.
+// It returns saved rates.
+func (r *RateRecorder) Record(ctx context.Context) (Rates, error) {
+	rates, err := r.source.Rates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Dates of the last saved rates are restored from the store, so restart doesn't duplicate them.
+	if r.last == nil {
+		latest, err := r.store.Latest(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		r.last = make(map[[2]int32]time.Time, len(latest))
+		for _, ex := range latest {
+			r.last[[2]int32{ex.CodeA, ex.CodeB}] = ex.Date.Time
+		}
+	}
+
+	changed := make(Rates, 0, len(rates))
+	for _, ex := range rates {
+		if last, ok := r.last[[2]int32{ex.CodeA, ex.CodeB}]; ok && !ex.Date.After(last) {
+			continue
+		}
+		changed = append(changed, ex)
+	}
+
+	if len(changed) == 0 {
+		return changed, nil
+	}
+
+	if err := r.store.Append(ctx, changed); err != nil {
+		return nil, err
+	}
+
+	for _, ex := range changed {
+		r.last[[2]int32{ex.CodeA, ex.CodeB}] = ex.Date.Time
+	}
+
+	return changed, nil
+}
+
+// Run records rates every interval until context is done.
+// Failures are reported to OnError callback and don't stop recording.
+func (r *RateRecorder) Run(ctx context.Context) error {
+	for {
+		if _, err := r.Record(ctx); err != nil && ctx.Err() == nil && r.onError != nil {
+			r.onError(err)
+		}
+
+		if err := sleep(ctx, r.interval); err != nil {
+			return err
+		}
+	}
+}
+
+// At returns rate of the pair in effect at specified time, which is the last one dated not after it.
+// If only reversed pair is recorded, it's inverted.
+func (r *RateRecorder) At(ctx context.Context, base, quote int32, at time.Time) (Exchange, error) {
+	history, err := r.store.History(ctx, base, quote, time.Time{}, at)
+	if err != nil {
+		return Exchange{}, err
+	}
+
+	if len(history) > 0 {
+		return history[len(history)-1], nil
+	}
+
+	history, err = r.store.History(ctx, quote, base, time.Time{}, at)
+	if err != nil {
+		return Exchange{}, err
+	}
+
+	if len(history) > 0 {
+		return history[len(history)-1].Inverse(), nil
+	}
+
+	return Exchange{}, fmt.Errorf("%w: %d/%d at %s", ErrNoRate, base, quote, at.Format(time.RFC3339))
+}
+
+// Range returns rates of the pair dated between from and to inclusive, ordered by date.
+// Rate in effect at from is included, even if it's dated earlier.
+// If only reversed pair is recorded, rates are inverted.
+func (r *RateRecorder) Range(ctx context.Context, base, quote int32, from, to time.Time) ([]Exchange, error) {
+	history, err := r.pairRange(ctx, base, quote, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) > 0 {
+		return history, nil
+	}
+
+	history, err = r.pairRange(ctx, quote, base, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range history {
+		history[i] = history[i].Inverse()
+	}
+
+	return history, nil
+}
+
+// pairRange returns rates of the pair in range, starting with the rate in effect at from.
+func (r *RateRecorder) pairRange(ctx context.Context, base, quote int32, from, to time.Time) ([]Exchange, error) {
+	history, err := r.store.History(ctx, base, quote, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.IsZero() || len(history) > 0 && history[0].Date.Equal(from) {
+		return history, nil
+	}
+
+	before, err := r.store.History(ctx, base, quote, time.Time{}, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(before) == 0 {
+		return history, nil
+	}
+
+	return append([]Exchange{before[len(before)-1]}, history...), nil
+}
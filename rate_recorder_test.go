@@ -0,0 +1,164 @@
+package mono
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRatesSource returns queued snapshots of rates, repeating the last one.
+type fakeRatesSource struct {
+	mu        sync.Mutex
+	snapshots []Rates
+	err       error
+	calls     int
+}
+
+func (s *fakeRatesSource) Rates(ctx context.Context) (Rates, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	rates := s.snapshots[0]
+	if len(s.snapshots) > 1 {
+		s.snapshots = s.snapshots[1:]
+	}
+
+	return rates, nil
+}
+
+func TestNewRateRecorder_Interval(t *testing.T) {
+	assertEqual(t, DefaultRecordInterval, NewRateRecorder(nil, nil, 0).interval)
+	assertEqual(t, MinRecordInterval, NewRateRecorder(nil, nil, time.Second).interval)
+	assertEqual(t, time.Hour, NewRateRecorder(nil, nil, time.Hour).interval)
+}
+
+func TestRateRecorder_Record(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeRatesSource{snapshots: []Rates{
+		{testRate(840, 980, 100, 1), testRate(978, 980, 100, 2)},
+		{testRate(840, 980, 100, 1), testRate(978, 980, 100, 2)},
+		{testRate(840, 980, 200, 3), testRate(978, 980, 100, 2)},
+	}}
+	store := NewMemoryRateStore()
+	recorder := NewRateRecorder(source, store, 0)
+
+	saved, err := recorder.Record(ctx)
+	assertEqual(t, nil, err)
+	assertEqual(t, 2, len(saved))
+
+	// Unchanged snapshot is not saved.
+	saved, err = recorder.Record(ctx)
+	assertEqual(t, nil, err)
+	assertEqual(t, 0, len(saved))
+
+	saved, err = recorder.Record(ctx)
+	assertEqual(t, nil, err)
+	assertEqual(t, Rates{testRate(840, 980, 200, 3)}, saved)
+
+	history, err := store.History(ctx, 840, 980, time.Time{}, time.Time{})
+	assertEqual(t, nil, err)
+	assertEqual(t, []Exchange{testRate(840, 980, 100, 1), testRate(840, 980, 200, 3)}, history)
+}
+
+func TestRateRecorder_RecordRestoresLatest(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRateStore()
+	if err := store.Append(ctx, []Exchange{testRate(840, 980, 100, 1)}); err != nil {
+		t.Fatal(err)
+	}
+
+	source := &fakeRatesSource{snapshots: []Rates{{testRate(840, 980, 100, 1)}}}
+	saved, err := NewRateRecorder(source, store, 0).Record(ctx)
+	assertEqual(t, nil, err)
+	assertEqual(t, 0, len(saved))
+}
+
+func TestRateRecorder_RecordError(t *testing.T) {
+	source := &fakeRatesSource{err: errors.New("too many requests")}
+	recorder := NewRateRecorder(source, NewMemoryRateStore(), 0)
+
+	_, err := recorder.Record(context.Background())
+	assertEqual(t, source.err, err)
+}
+
+func TestRateRecorder_Run(t *testing.T) {
+	source := &fakeRatesSource{err: errors.New("too many requests")}
+	recorder := NewRateRecorder(source, NewMemoryRateStore(), 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	recorder.OnError(func(err error) {
+		assertEqual(t, source.err, err)
+		cancel()
+	})
+
+	err := recorder.Run(ctx)
+	assertEqual(t, context.Canceled, err)
+	assertEqual(t, 1, source.calls)
+}
+
+func newTestRateRecorder(t *testing.T) *RateRecorder {
+	store := NewMemoryRateStore()
+	err := store.Append(context.Background(), []Exchange{
+		testRate(840, 980, 100, 40),
+		testRate(840, 980, 200, 41),
+		testRate(840, 980, 300, 42),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewRateRecorder(nil, store, 0)
+}
+
+func TestRateRecorder_At(t *testing.T) {
+	recorder := newTestRateRecorder(t)
+	ctx := context.Background()
+
+	ex, err := recorder.At(ctx, 840, 980, time.Unix(250, 0))
+	assertEqual(t, nil, err)
+	assertEqual(t, testRate(840, 980, 200, 41), ex)
+
+	ex, err = recorder.At(ctx, 840, 980, time.Unix(300, 0))
+	assertEqual(t, nil, err)
+	assertEqual(t, testRate(840, 980, 300, 42), ex)
+
+	ex, err = recorder.At(ctx, 980, 840, time.Unix(150, 0))
+	assertEqual(t, nil, err)
+	assertEqual(t, 1/40.0, ex.RateSell)
+	assertEqual(t, int32(980), ex.CodeA)
+
+	_, err = recorder.At(ctx, 840, 980, time.Unix(50, 0))
+	if !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected error: %v, actual error: %v", ErrNoRate, err)
+	}
+}
+
+func TestRateRecorder_Range(t *testing.T) {
+	recorder := newTestRateRecorder(t)
+	ctx := context.Background()
+
+	// Rate in effect at the start of the range is included.
+	history, err := recorder.Range(ctx, 840, 980, time.Unix(150, 0), time.Unix(300, 0))
+	assertEqual(t, nil, err)
+	assertEqual(t, []Exchange{testRate(840, 980, 100, 40), testRate(840, 980, 200, 41), testRate(840, 980, 300, 42)}, history)
+
+	history, err = recorder.Range(ctx, 840, 980, time.Unix(200, 0), time.Unix(250, 0))
+	assertEqual(t, nil, err)
+	assertEqual(t, []Exchange{testRate(840, 980, 200, 41)}, history)
+
+	history, err = recorder.Range(ctx, 980, 840, time.Time{}, time.Unix(150, 0))
+	assertEqual(t, nil, err)
+	assertEqual(t, 1, len(history))
+	assertEqual(t, 1/40.0, history[0].RateSell)
+
+	history, err = recorder.Range(ctx, 840, 980, time.Time{}, time.Unix(50, 0))
+	assertEqual(t, nil, err)
+	assertEqual(t, 0, len(history))
+}
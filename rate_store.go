@@ -0,0 +1,231 @@
+package mono
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RateStore is an interface for storing history of currency rates.
+type RateStore interface {
+	// Append saves rates, received from MonoBank API.
+	Append(ctx context.Context, rates []Exchange) error
+	// Latest returns the most recent saved rate of each pair.
+	Latest(ctx context.Context) (Rates, error)
+	// History returns rates of the pair dated between from and to inclusive, ordered by date.
+	// Zero from or to means the range is not limited from that side.
+	History(ctx context.Context, base, quote int32, from, to time.Time) ([]Exchange, error)
+}
+
+// rateSeries keeps rates of each pair ordered by date.
+type rateSeries map[[2]int32][]Exchange
+
+// add inserts rate keeping the order by date.
+func (s rateSeries) add(ex Exchange) {
+	key := [2]int32{ex.CodeA, ex.CodeB}
+	list := s[key]
+
+	i := sort.Search(len(list), func(i int) bool {
+		return list[i].Date.After(ex.Date.Time)
+	})
+
+	list = append(list, Exchange{})
+	copy(list[i+1:], list[i:])
+	list[i] = ex
+	s[key] = list
+}
+
+// latest returns the last rate of each pair.
+func (s rateSeries) latest() Rates {
+	rates := make(Rates, 0, len(s))
+	for _, list := range s {
+		rates = append(rates, list[len(list)-1])
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].CodeA != rates[j].CodeA {
+			return rates[i].CodeA < rates[j].CodeA
+		}
+		return rates[i].CodeB < rates[j].CodeB
+	})
+
+	return rates
+}
+
+// history returns copy of rates of the pair dated between from and to inclusive.
+func (s rateSeries) history(base, quote int32, from, to time.Time) []Exchange {
+	list := s[[2]int32{base, quote}]
+
+	start := 0
+	if !from.IsZero() {
+		start = sort.Search(len(list), func(i int) bool {
+			return !list[i].Date.Before(from)
+		})
+	}
+
+	end := len(list)
+	if !to.IsZero() {
+		end = sort.Search(len(list), func(i int) bool {
+			return list[i].Date.After(to)
+		})
+	}
+
+	if start >= end {
+		return nil
+	}
+
+	result := make([]Exchange, end-start)
+	copy(result, list[start:end])
+
+	return result
+}
+
+// MemoryRateStore keeps history of rates in memory.
+// It is safe for concurrent use by multiple goroutines.
+type MemoryRateStore struct {
+	mu     sync.RWMutex
+	series rateSeries
+}
+
+// NewMemoryRateStore returns new empty in-memory rate store.
+func NewMemoryRateStore() *MemoryRateStore {
+	return &MemoryRateStore{
+		series: make(rateSeries),
+	}
+}
+
+// Append saves rates, received from MonoBank API.
+func (s *MemoryRateStore) Append(ctx context.Context, rates []Exchange) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ex := range rates {
+		s.series.add(ex)
+	}
+
+	return nil
+}
+
+// Latest returns the most recent saved rate of each pair.
+func (s *MemoryRateStore) Latest(ctx context.Context) (Rates, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.series.latest(), nil
+}
+
+// History returns rates of the pair dated between from and to inclusive, ordered by date.
+func (s *MemoryRateStore) History(ctx context.Context, base, quote int32, from, to time.Time) ([]Exchange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.series.history(base, quote, from, to), nil
+}
+
+// JSONLRateStore keeps history of rates in file with one JSON encoded rate per line.
+// New rates are appended to the file, whole history is loaded into memory for queries.
+// It is safe for concurrent use by multiple goroutines of the single process.
+type JSONLRateStore struct {
+	mu     sync.RWMutex
+	path   string
+	series rateSeries
+}
+
+// NewJSONLRateStore returns rate store backed by file at path.
+// History is loaded from the file, if it exists.
+func NewJSONLRateStore(path string) (*JSONLRateStore, error) {
+	store := &JSONLRateStore{
+		path:   path,
+		series: make(rateSeries),
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var ex Exchange
+		if err := json.Unmarshal(data, &ex); err != nil {
+			return nil, fmt.Errorf("failed to decode rate from %s:%d: %w", path, line, err)
+		}
+
+		store.series.add(ex)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Append saves rates, received from MonoBank API.
+// Rates are written to the file with a single write, so they are not interleaved with other records.
+func (s *JSONLRateStore) Append(ctx context.Context, rates []Exchange) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, ex := range rates {
+		if err := enc.Encode(ex); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	for _, ex := range rates {
+		s.series.add(ex)
+	}
+
+	return nil
+}
+
+// Latest returns the most recent saved rate of each pair.
+func (s *JSONLRateStore) Latest(ctx context.Context) (Rates, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.series.latest(), nil
+}
+
+// History returns rates of the pair dated between from and to inclusive, ordered by date.
+func (s *JSONLRateStore) History(ctx context.Context, base, quote int32, from, to time.Time) ([]Exchange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.series.history(base, quote, from, to), nil
+}
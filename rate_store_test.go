@@ -0,0 +1,101 @@
+package mono
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testRate(base, quote int32, sec int64, buy float64) Exchange {
+	return Exchange{CodeA: base, CodeB: quote, Date: Time{time.Unix(sec, 0).UTC()}, RateBuy: buy}
+}
+
+func testRateStore(t *testing.T, store RateStore) {
+	ctx := context.Background()
+
+	err := store.Append(ctx, []Exchange{testRate(840, 980, 300, 3), testRate(978, 980, 100, 4)})
+	assertEqual(t, nil, err)
+	// Rates out of order are sorted by date.
+	err = store.Append(ctx, []Exchange{testRate(840, 980, 100, 1), testRate(840, 980, 200, 2)})
+	assertEqual(t, nil, err)
+
+	t.Run("latest", func(t *testing.T) {
+		latest, err := store.Latest(ctx)
+		assertEqual(t, nil, err)
+		assertEqual(t, Rates{testRate(840, 980, 300, 3), testRate(978, 980, 100, 4)}, latest)
+	})
+
+	t.Run("history", func(t *testing.T) {
+		history, err := store.History(ctx, 840, 980, time.Time{}, time.Time{})
+		assertEqual(t, nil, err)
+		assertEqual(t, []Exchange{testRate(840, 980, 100, 1), testRate(840, 980, 200, 2), testRate(840, 980, 300, 3)}, history)
+	})
+
+	t.Run("history inclusive range", func(t *testing.T) {
+		history, err := store.History(ctx, 840, 980, time.Unix(200, 0), time.Unix(300, 0))
+		assertEqual(t, nil, err)
+		assertEqual(t, []Exchange{testRate(840, 980, 200, 2), testRate(840, 980, 300, 3)}, history)
+	})
+
+	t.Run("history of unknown pair", func(t *testing.T) {
+		history, err := store.History(ctx, 980, 840, time.Time{}, time.Time{})
+		assertEqual(t, nil, err)
+		assertEqual(t, 0, len(history))
+	})
+}
+
+func TestMemoryRateStore(t *testing.T) {
+	testRateStore(t, NewMemoryRateStore())
+}
+
+func TestJSONLRateStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mono")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rates.jsonl")
+
+	store, err := NewJSONLRateStore(path)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+	testRateStore(t, store)
+
+	t.Run("writes line per rate", func(t *testing.T) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `{"currencyCodeA":840,"currencyCodeB":980,"date":300,"rateSell":0,"rateBuy":3,"rateCross":0}` + "\n"
+		assertEqual(t, expected, string(data[:len(expected)]))
+	})
+
+	t.Run("loads saved rates", func(t *testing.T) {
+		store, err := NewJSONLRateStore(path)
+		if err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+		history, err := store.History(context.Background(), 840, 980, time.Time{}, time.Time{})
+		assertEqual(t, nil, err)
+		assertEqual(t, 3, len(history))
+		assertEqual(t, int64(100), history[0].Date.Unix())
+	})
+
+	t.Run("fails on invalid file", func(t *testing.T) {
+		invalid := filepath.Join(dir, "invalid.jsonl")
+		if err := ioutil.WriteFile(invalid, []byte("{}\n{"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := NewJSONLRateStore(invalid)
+		if err == nil {
+			t.Error("expected decoding error")
+		}
+	})
+}
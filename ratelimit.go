@@ -0,0 +1,145 @@
+package mono
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimits returns minimal intervals between requests to MonoBank API endpoint families.
+// Checks of corporate auth requests are limited to DefaultAuthPollInterval per request ID,
+// requests are issued by Auth within the same limit per key.
+// See https://api.monobank.ua/docs/ for details.
+func DefaultRateLimits() map[string]time.Duration {
+	return map[string]time.Duration{
+		"/bank/currency":         time.Minute,
+		"/personal/client-info":  time.Minute,
+		"/personal/statement":    time.Minute,
+		"/personal/auth/request": DefaultAuthPollInterval,
+	}
+}
+
+// DefaultRateLimiter is a limiter with DefaultRateLimits shared by all clients, which use it.
+// Clients are not limited by default, enable it with WithRateLimiter(DefaultRateLimiter),
+// so clients with the same token share the limits within the process.
+var DefaultRateLimiter = NewRateLimiter(DefaultRateLimits())
+
+// limiterKey returns identity of the client for the limiter.
+// Token is hashed, so it's not kept in memory of the limiter, request ID is separated from it.
+func limiterKey(identity, requestID string) string {
+	sum := sha256.Sum256([]byte(identity))
+	return hex.EncodeToString(sum[:]) + "/" + requestID
+}
+
+// RateLimiter delays requests to keep them within MonoBank API limits.
+// Limits are applied per identity (token, key or request ID) and endpoint family.
+// It is safe for concurrent use by multiple goroutines and clients.
+type RateLimiter struct {
+	mu      sync.Mutex
+	limits  map[string]time.Duration
+	next    map[string]time.Time
+	sweptAt time.Time
+}
+
+// limiterSweepInterval is interval between removals of expired slots,
+// so limiter doesn't grow with each new identity.
+const limiterSweepInterval = time.Minute
+
+// NewRateLimiter returns new rate limiter with specified intervals per endpoint family.
+// Family is a path prefix, requests to endpoints outside of any family are not limited.
+func NewRateLimiter(limits map[string]time.Duration) *RateLimiter {
+	ll := make(map[string]time.Duration, len(limits))
+	for family, interval := range limits {
+		ll[path.Clean("/"+family)] = interval
+	}
+
+	return &RateLimiter{
+		limits: ll,
+		next:   make(map[string]time.Time),
+	}
+}
+
+// family returns the most specific endpoint family and it's interval.
+func (l *RateLimiter) family(endpoint string) (string, time.Duration) {
+	endpoint = path.Clean("/" + strings.SplitN(endpoint, "?", 2)[0])
+
+	var family string
+	var interval time.Duration
+
+	for prefix, limit := range l.limits {
+		if endpoint != prefix && !strings.HasPrefix(endpoint, prefix+"/") {
+			continue
+		}
+
+		if len(prefix) > len(family) {
+			family, interval = prefix, limit
+		}
+	}
+
+	return family, interval
+}
+
+// Wait blocks until request to the endpoint on behalf of identity is allowed or context is done.
+func (l *RateLimiter) Wait(ctx context.Context, identity, endpoint string) error {
+	family, interval := l.family(endpoint)
+	if interval <= 0 {
+		return nil
+	}
+
+	key := identity + " " + family
+
+	l.mu.Lock()
+	now := time.Now()
+	l.sweep(now)
+	slot := l.next[key]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[key] = slot.Add(interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release(key, slot, interval)
+		return ctx.Err()
+	}
+}
+
+// sweep removes slots, which are already in the past, they don't delay requests anymore.
+// It must be called with mutex held.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.sweptAt) < limiterSweepInterval {
+		return
+	}
+
+	for key, slot := range l.next {
+		if !slot.After(now) {
+			delete(l.next, key)
+		}
+	}
+	l.sweptAt = now
+}
+
+// release gives back reserved slot, if nobody has queued after it.
+func (l *RateLimiter) release(key string, slot time.Time, interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.next[key].Equal(slot.Add(interval)) {
+		l.next[key] = slot
+	}
+}
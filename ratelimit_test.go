@@ -0,0 +1,166 @@
+package mono
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiter(map[string]time.Duration{
+		"/personal/statement": 50 * time.Millisecond,
+	})
+
+	t.Run("delays requests to the same family", func(t *testing.T) {
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			if err := limiter.Wait(context.Background(), "token", "/personal/statement/0/1/2"); err != nil {
+				t.Fatalf("expected error: nil, actual error: %v", err)
+			}
+		}
+
+		if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+			t.Errorf("expected delay at least 100ms, actual %s", elapsed)
+		}
+	})
+
+	t.Run("does not limit other identities and endpoints", func(t *testing.T) {
+		start := time.Now()
+		for _, identity := range []string{"token1", "token2"} {
+			if err := limiter.Wait(context.Background(), identity, "/personal/statement/0/1/2"); err != nil {
+				t.Fatalf("expected error: nil, actual error: %v", err)
+			}
+		}
+		for i := 0; i < 3; i++ {
+			if err := limiter.Wait(context.Background(), "token1", "/personal/client-info"); err != nil {
+				t.Fatalf("expected error: nil, actual error: %v", err)
+			}
+		}
+
+		if elapsed := time.Since(start); elapsed > 25*time.Millisecond {
+			t.Errorf("expected no delay, actual %s", elapsed)
+		}
+	})
+
+	t.Run("respects context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_ = limiter.Wait(context.Background(), "token3", "/personal/statement")
+		err := limiter.Wait(ctx, "token3", "/personal/statement")
+		assertEqual(t, context.DeadlineExceeded, err)
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = limiter.Wait(context.Background(), "token4", "/personal/statement")
+			}()
+		}
+		wg.Wait()
+
+		if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+			t.Errorf("expected delay at least 150ms, actual %s", elapsed)
+		}
+	})
+}
+
+func TestCore_RateLimiter(t *testing.T) {
+	core := newCore()
+	core.SetRateLimiter(NewRateLimiter(map[string]time.Duration{
+		"/bank/currency": time.Hour,
+	}))
+
+	srv, _ := FakeServer("[]", http.StatusOK)
+	core.SetBaseURL(srv.URL)
+	defer srv.Close()
+
+	if _, err := core.Rates(context.Background()); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := core.Rates(ctx)
+	assertEqual(t, context.DeadlineExceeded, err)
+}
+
+func TestRateLimiter_Sweep(t *testing.T) {
+	limiter := NewRateLimiter(map[string]time.Duration{"/personal/statement": time.Millisecond})
+
+	for _, identity := range []string{"req1", "req2", "req3"} {
+		if err := limiter.Wait(context.Background(), identity, "/personal/statement"); err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+	}
+	assertEqual(t, 3, len(limiter.next))
+
+	// Slots of finished requests are removed.
+	time.Sleep(2 * time.Millisecond)
+	limiter.sweptAt = time.Time{}
+
+	if err := limiter.Wait(context.Background(), "req4", "/personal/statement"); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+	assertEqual(t, 1, len(limiter.next))
+}
+
+func TestDefaultRateLimits(t *testing.T) {
+	limiter := NewRateLimiter(DefaultRateLimits())
+
+	family, interval := limiter.family("/personal/auth/request")
+	assertEqual(t, "/personal/auth/request", family)
+	assertEqual(t, DefaultAuthPollInterval, interval)
+}
+
+func TestLimiterKey(t *testing.T) {
+	key := limiterKey("token", "req")
+
+	if strings.Contains(key, "token") {
+		t.Errorf("expected hashed token, actual key: %s", key)
+	}
+	assertEqual(t, key, limiterKey("token", "req"))
+
+	// Identity and request ID are separated, so their concatenation is not ambiguous.
+	if limiterKey("token", "1") == limiterKey("token1", "") {
+		t.Error("expected different keys for different identities")
+	}
+}
+
+func TestCore_SharedRateLimiter(t *testing.T) {
+	srv, _ := FakeServer("{}", http.StatusOK)
+	defer srv.Close()
+
+	assertEqual(t, (*RateLimiter)(nil), NewPersonal("token").limiter)
+
+	limiter := NewRateLimiter(map[string]time.Duration{
+		"/personal/client-info": time.Hour,
+	})
+
+	first := NewPersonal("token", WithBaseURL(srv.URL), WithRateLimiter(limiter))
+	second := NewPersonal("token", WithBaseURL(srv.URL), WithRateLimiter(limiter))
+
+	if _, err := first.User(context.Background()); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := second.User(ctx)
+	assertEqual(t, context.DeadlineExceeded, err)
+
+	other := NewPersonal("other", WithBaseURL(srv.URL), WithRateLimiter(limiter))
+	if _, err := other.User(context.Background()); err != nil {
+		t.Errorf("expected error: nil, actual error: %v", err)
+	}
+}
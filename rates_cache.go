@@ -0,0 +1,170 @@
+package mono
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRatesTTL is interval, after which cached rates are refreshed.
+// MonoBank updates rates not more often than every 5 minutes.
+const DefaultRatesTTL = 5 * time.Minute
+
+// RatesRetryInterval is interval after failed refresh, during which rates are not requested again.
+// It matches the limit of MonoBank API for currency rates.
+const RatesRetryInterval = time.Minute
+
+// CachedRates is a snapshot of currency rates served by RatesCache.
+type CachedRates struct {
+	Rates     Rates     // Currency rates.
+	FetchedAt time.Time // Time, when rates were received from MonoBank API.
+	Stale     bool      // Rates are older than TTL, because refresh is in progress or failed.
+	Err       error     // Error of the last failed refresh, if rates are stale.
+}
+
+// ratesFetcher requests rates from MonoBank API.
+type ratesFetcher func(ctx context.Context) (Rates, error)
+
+// ratesRefresh is a single request of rates, shared by all callers waiting for it.
+type ratesRefresh struct {
+	done      chan struct{}
+	rates     Rates
+	fetchedAt time.Time
+	err       error
+}
+
+// RatesCache keeps currency rates for TTL and refreshes them in background afterwards,
+// serving stale rates while refresh is in progress or when it fails.
+// After failure rates are not requested for RatesRetryInterval, stale rates or the error are served instead.
+// Cache can be shared between clients, it is safe for concurrent use by multiple goroutines.
+// Refresh is made by the client, which is the first to request expired rates, with its options.
+type RatesCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	maxStale time.Duration
+	entry    *CachedRates
+	lastErr  error
+	retryAt  time.Time
+	inflight *ratesRefresh
+
+	now func() time.Time
+}
+
+// NewRatesCache returns cache, which keeps rates fresh for ttl.
+// Stale rates are served for at most maxStale after expiration, zero allows to serve them indefinitely.
+func NewRatesCache(ttl, maxStale time.Duration) *RatesCache {
+	if ttl <= 0 {
+		ttl = DefaultRatesTTL
+	}
+
+	return &RatesCache{
+		ttl:      ttl,
+		maxStale: maxStale,
+		now:      time.Now,
+	}
+}
+
+// refresh starts request of rates in background, unless it's already in progress.
+// It must be called with mutex held.
+func (rc *RatesCache) refresh(fetch ratesFetcher) *ratesRefresh {
+	if rc.inflight != nil {
+		return rc.inflight
+	}
+
+	r := &ratesRefresh{done: make(chan struct{})}
+	rc.inflight = r
+
+	go func() {
+		// Refresh is shared by callers, so it is not bound to context of any of them.
+		r.rates, r.err = fetch(context.Background())
+
+		rc.mu.Lock()
+		if r.err == nil {
+			r.fetchedAt = rc.now()
+			rc.entry = &CachedRates{Rates: r.rates, FetchedAt: r.fetchedAt}
+			rc.lastErr = nil
+			rc.retryAt = time.Time{}
+		} else {
+			rc.lastErr = r.err
+			rc.retryAt = rc.now().Add(RatesRetryInterval)
+		}
+		rc.inflight = nil
+		rc.mu.Unlock()
+
+		close(r.done)
+	}()
+
+	return r
+}
+
+// snapshot returns copy of cached rates, so callers can't modify them.
+func snapshot(entry *CachedRates, stale bool, err error) *CachedRates {
+	rates := make(Rates, len(entry.Rates))
+	copy(rates, entry.Rates)
+
+	return &CachedRates{
+		Rates:     rates,
+		FetchedAt: entry.FetchedAt,
+		Stale:     stale,
+		Err:       err,
+	}
+}
+
+// backoff reports whether the last refresh failed less than RatesRetryInterval ago.
+// It must be called with mutex held.
+func (rc *RatesCache) backoff() bool {
+	return rc.lastErr != nil && rc.now().Before(rc.retryAt)
+}
+
+// get returns cached rates, requesting them with fetch when cache is empty or expired.
+// Fetch of the caller, which starts refresh, is shared by all callers waiting for it.
+func (rc *RatesCache) get(ctx context.Context, fetch ratesFetcher) (*CachedRates, error) {
+	rc.mu.Lock()
+
+	if entry := rc.entry; entry != nil {
+		age := rc.now().Sub(entry.FetchedAt)
+		if age < rc.ttl {
+			rc.mu.Unlock()
+			return snapshot(entry, false, nil), nil
+		}
+
+		if rc.maxStale <= 0 || age < rc.ttl+rc.maxStale {
+			if !rc.backoff() {
+				rc.refresh(fetch)
+			}
+			err := rc.lastErr
+			rc.mu.Unlock()
+			return snapshot(entry, true, err), nil
+		}
+	}
+
+	if rc.inflight == nil && rc.backoff() {
+		err := rc.lastErr
+		rc.mu.Unlock()
+		return nil, err
+	}
+
+	r := rc.refresh(fetch)
+	rc.mu.Unlock()
+
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return snapshot(&CachedRates{Rates: r.rates, FetchedAt: r.fetchedAt}, false, nil), nil
+}
+
+// Invalidate drops cached rates, so the next call requests them from MonoBank API.
+func (rc *RatesCache) Invalidate() {
+	rc.mu.Lock()
+	rc.entry = nil
+	rc.lastErr = nil
+	rc.retryAt = time.Time{}
+	rc.mu.Unlock()
+}
@@ -0,0 +1,267 @@
+package mono
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for testing expiration.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// fakeRatesFetcher returns rates with increasing date and counts requests.
+type fakeRatesFetcher struct {
+	calls int32
+	err   atomic.Value
+}
+
+func (f *fakeRatesFetcher) fetch(ctx context.Context) (Rates, error) {
+	n := atomic.AddInt32(&f.calls, 1)
+	if err, ok := f.err.Load().(error); ok && err != nil {
+		return nil, err
+	}
+
+	return Rates{{CodeA: 840, CodeB: 980, Date: Time{time.Unix(int64(n), 0).UTC()}}}, nil
+}
+
+func newTestRatesCache(ttl, maxStale time.Duration) (*RatesCache, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	cache := NewRatesCache(ttl, maxStale)
+	cache.now = clock.Now
+
+	return cache, clock
+}
+
+// waitRefresh waits until background refresh is finished.
+func waitRefresh(cache *RatesCache) {
+	cache.mu.Lock()
+	r := cache.inflight
+	cache.mu.Unlock()
+
+	if r != nil {
+		<-r.done
+	}
+}
+
+func TestRatesCache_Fresh(t *testing.T) {
+	cache, clock := newTestRatesCache(time.Minute, 0)
+	fetcher := new(fakeRatesFetcher)
+
+	for i := 0; i < 3; i++ {
+		rates, err := cache.get(context.Background(), fetcher.fetch)
+		if err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+
+		assertEqual(t, false, rates.Stale)
+		assertEqual(t, int64(1), rates.Rates[0].Date.Unix())
+		clock.Advance(10 * time.Second)
+	}
+
+	assertEqual(t, int32(1), atomic.LoadInt32(&fetcher.calls))
+}
+
+func TestRatesCache_StaleWhileRevalidate(t *testing.T) {
+	cache, clock := newTestRatesCache(time.Minute, 0)
+	fetcher := new(fakeRatesFetcher)
+
+	if _, err := cache.get(context.Background(), fetcher.fetch); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	clock.Advance(2 * time.Minute)
+
+	rates, err := cache.get(context.Background(), fetcher.fetch)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, true, rates.Stale)
+	assertEqual(t, int64(1), rates.Rates[0].Date.Unix())
+
+	waitRefresh(cache)
+
+	rates, err = cache.get(context.Background(), fetcher.fetch)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, false, rates.Stale)
+	assertEqual(t, int64(2), rates.Rates[0].Date.Unix())
+	assertEqual(t, int32(2), atomic.LoadInt32(&fetcher.calls))
+}
+
+func TestRatesCache_StaleOnError(t *testing.T) {
+	cache, clock := newTestRatesCache(time.Minute, 5*time.Minute)
+	fetcher := new(fakeRatesFetcher)
+
+	if _, err := cache.get(context.Background(), fetcher.fetch); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	failure := errors.New("unavailable")
+	fetcher.err.Store(failure)
+	clock.Advance(2 * time.Minute)
+
+	if _, err := cache.get(context.Background(), fetcher.fetch); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+	waitRefresh(cache)
+
+	rates, err := cache.get(context.Background(), fetcher.fetch)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+	waitRefresh(cache)
+
+	assertEqual(t, true, rates.Stale)
+	assertEqual(t, failure, rates.Err)
+	assertEqual(t, int64(1), rates.Rates[0].Date.Unix())
+
+	// Rates older than TTL and max staleness are not served anymore.
+	clock.Advance(5 * time.Minute)
+
+	if _, err := cache.get(context.Background(), fetcher.fetch); !errors.Is(err, failure) {
+		t.Errorf("expected error: %v, actual error: %v", failure, err)
+	}
+}
+
+func TestRatesCache_RetryInterval(t *testing.T) {
+	failure := errors.New("unavailable")
+
+	t.Run("stale rates", func(t *testing.T) {
+		cache, clock := newTestRatesCache(time.Minute, 0)
+		fetcher := new(fakeRatesFetcher)
+
+		if _, err := cache.get(context.Background(), fetcher.fetch); err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+
+		fetcher.err.Store(failure)
+		clock.Advance(2 * time.Minute)
+
+		for i := 0; i < 3; i++ {
+			rates, err := cache.get(context.Background(), fetcher.fetch)
+			if err != nil {
+				t.Fatalf("expected error: nil, actual error: %v", err)
+			}
+			waitRefresh(cache)
+
+			assertEqual(t, true, rates.Stale)
+		}
+
+		assertEqual(t, int32(2), atomic.LoadInt32(&fetcher.calls))
+
+		clock.Advance(RatesRetryInterval)
+
+		if _, err := cache.get(context.Background(), fetcher.fetch); err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+		waitRefresh(cache)
+
+		assertEqual(t, int32(3), atomic.LoadInt32(&fetcher.calls))
+	})
+
+	t.Run("empty cache", func(t *testing.T) {
+		cache, clock := newTestRatesCache(time.Minute, 0)
+		fetcher := new(fakeRatesFetcher)
+		fetcher.err.Store(failure)
+
+		for i := 0; i < 3; i++ {
+			if _, err := cache.get(context.Background(), fetcher.fetch); !errors.Is(err, failure) {
+				t.Errorf("expected error: %v, actual error: %v", failure, err)
+			}
+		}
+
+		assertEqual(t, int32(1), atomic.LoadInt32(&fetcher.calls))
+
+		clock.Advance(RatesRetryInterval)
+
+		recovered := new(fakeRatesFetcher)
+		if _, err := cache.get(context.Background(), recovered.fetch); err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+
+		assertEqual(t, int32(1), atomic.LoadInt32(&recovered.calls))
+	})
+}
+
+func TestRatesCache_SingleRequest(t *testing.T) {
+	cache, _ := newTestRatesCache(time.Minute, 0)
+
+	var calls int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) (Rates, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return Rates{{CodeA: 840, CodeB: 980}}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.get(context.Background(), fetch); err != nil {
+				t.Errorf("expected error: nil, actual error: %v", err)
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assertEqual(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestPublic_Rates_Cache(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte(`[{"currencyCodeA":840,"currencyCodeB":980,"date":1700000000,"rateBuy":41.0,"rateSell":41.5}]`))
+	}))
+	defer srv.Close()
+
+	cache := NewRatesCache(time.Minute, 0)
+	first := NewPublic(WithBaseURL(srv.URL), WithRateLimiter(nil), WithRatesCache(cache))
+	second := NewPublic(WithBaseURL(srv.URL), WithRateLimiter(nil), WithRatesCache(cache))
+
+	for _, client := range []*Public{first, second, first} {
+		rates, err := client.Rates(context.Background())
+		if err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+
+		assertEqual(t, 1, len(rates))
+	}
+
+	assertEqual(t, int32(1), atomic.LoadInt32(&requests))
+
+	cached, err := second.CachedRates(context.Background())
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, false, cached.Stale)
+}
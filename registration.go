@@ -0,0 +1,139 @@
+package mono
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// RegistrationStatusType is a state of corporate API provider registration.
+type RegistrationStatusType string
+
+const (
+	// RegistrationNew means, that request is received, but not reviewed yet.
+	RegistrationNew RegistrationStatusType = "New"
+	// RegistrationWaitingForConfirm means, that request is waiting for confirmation by MonoBank.
+	RegistrationWaitingForConfirm RegistrationStatusType = "WaitingForConfirm"
+	// RegistrationApproved means, that service is allowed to use corporate API.
+	RegistrationApproved RegistrationStatusType = "Approved"
+	// RegistrationDeclined means, that request is declined.
+	RegistrationDeclined RegistrationStatusType = "Declined"
+)
+
+// Registration is a request to become corporate API provider.
+// See https://api.monobank.ua/docs/corporate.html#operation--personal-auth-registration-post for details.
+type Registration struct {
+	Name          string `json:"name"`          // Name of the service.
+	Description   string `json:"description"`   // Description of the service.
+	ContactPerson string `json:"contactPerson"` // Full name of the contact person.
+	Phone         string `json:"phone"`         // Phone number of the contact person.
+	Email         string `json:"email"`         // Email of the contact person.
+	Logo          []byte `json:"logo"`          // Logo of the service in PNG format.
+}
+
+// RegistrationStatus is a state of corporate API provider registration.
+type RegistrationStatus struct {
+	Status RegistrationStatusType `json:"status"`
+	KeyID  string                 `json:"keyId"` // Key identifier, available after approval.
+}
+
+// CorporateSettings is a configuration of corporate API provider.
+// See https://api.monobank.ua/docs/corporate.html#operation--personal-corp-settings-post for details.
+type CorporateSettings struct {
+	PublicKey  string `json:"pubkey"`     // Base64 encoded public key of the service.
+	Name       string `json:"name"`       // Name of the service.
+	Permission string `json:"permission"` // Permissions available to the service.
+	Logo       string `json:"logo"`       // Base64 encoded logo of the service.
+	WebHook    string `json:"webhook"`    // URL for receiving new transactions of clients.
+}
+
+// signedPost makes signed POST request with JSON payload, response is decoded into v.
+func (c *Corporate) signedPost(ctx context.Context, endpoint string, payload interface{}, v interface{}) error {
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+
+	buff, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.signed(endpoint).call(ctx, http.MethodPost, endpoint, headers, bytes.NewReader(buff))
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// publicKey returns base64 encoded PEM public key of the service.
+func (c *Corporate) publicKey() (string, error) {
+	return c.auth.EncodePublicKeyString(c.auth.PublicKey)
+}
+
+// Register sends request to become corporate API provider with the public key of the client.
+// See https://api.monobank.ua/docs/corporate.html#operation--personal-auth-registration-post for details.
+func (c *Corporate) Register(ctx context.Context, reg *Registration) (*RegistrationStatus, error) {
+	pubkey, err := c.publicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := struct {
+		PublicKey string `json:"pubkey"`
+		*Registration
+	}{pubkey, reg}
+
+	status := new(RegistrationStatus)
+	if err := c.signedPost(ctx, "/personal/auth/registration", payload, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// RegistrationStatus returns state of registration of the public key of the client.
+// See https://api.monobank.ua/docs/corporate.html#operation--personal-auth-registration-status-post for details.
+func (c *Corporate) RegistrationStatus(ctx context.Context) (*RegistrationStatus, error) {
+	pubkey, err := c.publicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := struct {
+		PublicKey string `json:"pubkey"`
+	}{pubkey}
+
+	status := new(RegistrationStatus)
+	if err := c.signedPost(ctx, "/personal/auth/registration/status", payload, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Settings returns configuration of the corporate API provider.
+// See https://api.monobank.ua/docs/corporate.html#operation--personal-corp-settings-post for details.
+func (c *Corporate) Settings(ctx context.Context) (*CorporateSettings, error) {
+	settings := new(CorporateSettings)
+	if err := c.signedPost(ctx, "/personal/corp/settings", struct{}{}, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// SetWebHook sets URL for receiving new transactions of all clients of the service.
+// See https://api.monobank.ua/docs/corporate.html#operation--personal-corp-webhook-post for details.
+func (c *Corporate) SetWebHook(ctx context.Context, url string) error {
+	payload := struct {
+		WebHookURL string `json:"webHookUrl"`
+	}{url}
+
+	return c.signedPost(ctx, "/personal/corp/webhook", payload, nil)
+}
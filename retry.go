@@ -0,0 +1,130 @@
+package mono
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy describes how failed requests to MonoBank API are repeated.
+// Signed requests of corporate API are signed again on each attempt.
+// Only idempotent requests are retried, POST requests are retried only when
+// context is marked with WithRetrySafe.
+type RetryPolicy struct {
+	MaxAttempts        int           // Total number of attempts including the first one.
+	BaseDelay          time.Duration // Delay before the first retry, doubled on each next one.
+	MaxDelay           time.Duration // Upper bound of delay between attempts.
+	Jitter             float64       // Fraction of delay, which is randomized, from 0 to 1.
+	RetryStatuses      []int         // HTTP statuses, which are worth retrying.
+	RetryNetworkErrors bool          // Retry on transport errors like connection reset or timeout.
+}
+
+// DefaultRetryPolicy returns retry policy with some reasonable defaults.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Second,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		RetryStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+type retrySafeKey struct{}
+
+// WithRetrySafe marks requests made with returned context as safe to retry,
+// even if they are not idempotent by HTTP semantics.
+func WithRetrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey{}, true)
+}
+
+func isRetrySafe(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	safe, _ := ctx.Value(retrySafeKey{}).(bool)
+	return safe
+}
+
+// retryable reports whether another attempt should be made after response or error.
+func (p *RetryPolicy) retryable(resp *response, err error) bool {
+	if err != nil {
+		return p.RetryNetworkErrors && isNetworkError(err)
+	}
+
+	for _, status := range p.RetryStatuses {
+		if resp.status == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNetworkError reports whether error is a transport failure, which may not happen again.
+// Errors of building request, authorization and decoding can't be fixed by retrying,
+// as well as cancellation of the request.
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	// Invalid URL is reported with the same type as transport failures.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Op != "parse"
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// delay returns pause before the next attempt, respecting Retry-After header of the response.
+func (p *RetryPolicy) delay(attempt int, resp *response) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		delay -= time.Duration(p.Jitter * rand.Float64() * float64(delay))
+	}
+
+	if resp != nil {
+		if after := parseRetryAfter(resp.header.Get("Retry-After")); after > delay {
+			delay = after
+		}
+	}
+
+	return delay
+}
+
+// sleep pauses for specified duration or until context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,233 @@
+package mono
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func FlakyServer(failures int, status int) (*httptest.Server, *int) {
+	attempts := new(int)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*attempts++
+		payload, _ := ioutil.ReadAll(r.Body)
+
+		if *attempts <= failures {
+			w.WriteHeader(status)
+			return
+		}
+
+		_, _ = w.Write(payload)
+	})
+
+	return httptest.NewServer(handler), attempts
+}
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+
+	return policy
+}
+
+func TestCore_Retry(t *testing.T) {
+	t.Run("retries GET requests", func(t *testing.T) {
+		srv, attempts := FlakyServer(2, http.StatusServiceUnavailable)
+		defer srv.Close()
+
+		core := newCore()
+		core.SetBaseURL(srv.URL)
+		core.SetRetryPolicy(testRetryPolicy())
+
+		_, status, err := core.GetJSON(context.Background(), "/", nil)
+		assertEqual(t, nil, err)
+		assertEqual(t, http.StatusOK, status)
+		assertEqual(t, 3, *attempts)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		srv, attempts := FlakyServer(5, http.StatusTooManyRequests)
+		defer srv.Close()
+
+		core := newCore()
+		core.SetBaseURL(srv.URL)
+		core.SetRetryPolicy(testRetryPolicy())
+		core.SetRateLimiter(nil)
+
+		_, err := core.Rates(context.Background())
+		if !errors.Is(err, ErrTooManyRequests) {
+			t.Errorf("expected %v, got %v", ErrTooManyRequests, err)
+		}
+		assertEqual(t, 3, *attempts)
+	})
+
+	t.Run("does not retry other statuses", func(t *testing.T) {
+		srv, attempts := FlakyServer(1, http.StatusBadRequest)
+		defer srv.Close()
+
+		core := newCore()
+		core.SetBaseURL(srv.URL)
+		core.SetRetryPolicy(testRetryPolicy())
+
+		_, status, _ := core.GetJSON(context.Background(), "/", nil)
+		assertEqual(t, http.StatusBadRequest, status)
+		assertEqual(t, 1, *attempts)
+	})
+
+	t.Run("does not retry POST requests", func(t *testing.T) {
+		srv, attempts := FlakyServer(1, http.StatusServiceUnavailable)
+		defer srv.Close()
+
+		core := newCore()
+		core.SetBaseURL(srv.URL)
+		core.SetRetryPolicy(testRetryPolicy())
+
+		_, status, _ := core.PostJSON(context.Background(), "/", nil, bytes.NewReader([]byte("Body")))
+		assertEqual(t, http.StatusServiceUnavailable, status)
+		assertEqual(t, 1, *attempts)
+	})
+
+	t.Run("retries POST requests marked as safe", func(t *testing.T) {
+		srv, attempts := FlakyServer(1, http.StatusServiceUnavailable)
+		defer srv.Close()
+
+		core := newCore()
+		core.SetBaseURL(srv.URL)
+		core.SetRetryPolicy(testRetryPolicy())
+
+		ctx := WithRetrySafe(context.Background())
+		body, status, _ := core.PostJSON(ctx, "/", nil, bytes.NewReader([]byte("Body")))
+		assertEqual(t, http.StatusOK, status)
+		assertEqual(t, "Body", string(body))
+		assertEqual(t, 2, *attempts)
+	})
+
+	t.Run("retries network errors", func(t *testing.T) {
+		srv, _ := FakeServer("Body", http.StatusOK)
+		srv.Close()
+
+		core := newCore()
+		core.SetBaseURL(srv.URL)
+		core.SetRetryPolicy(testRetryPolicy())
+
+		start := time.Now()
+		_, _, err := core.GetJSON(context.Background(), "/", nil)
+		if err == nil {
+			t.Error("expected network error")
+		}
+		if time.Since(start) < time.Millisecond {
+			t.Error("expected delay between attempts")
+		}
+	})
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := &RetryPolicy{
+		BaseDelay: time.Second,
+		MaxDelay:  5 * time.Second,
+	}
+
+	assertEqual(t, time.Second, policy.delay(1, nil))
+	assertEqual(t, 2*time.Second, policy.delay(2, nil))
+	assertEqual(t, 4*time.Second, policy.delay(3, nil))
+	assertEqual(t, 5*time.Second, policy.delay(4, nil))
+
+	resp := &response{header: http.Header{"Retry-After": []string{"10"}}}
+	assertEqual(t, 10*time.Second, policy.delay(1, resp))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		if d := policy.delay(1, nil); d < 500*time.Millisecond || d > time.Second {
+			t.Errorf("delay %s is out of jitter range", d)
+		}
+	}
+
+	t.Run("does not retry errors of building request", func(t *testing.T) {
+		core := newCore()
+		core.SetBaseURL("://invalid")
+		core.SetRetryPolicy(testRetryPolicy())
+
+		calls := 0
+		auth := authorizerFunc(func(*http.Request) error {
+			calls++
+			return nil
+		})
+
+		start := time.Now()
+		_, err := core.request(context.Background(), http.MethodGet, "/", nil, nil, auth)
+		if err == nil {
+			t.Error("expected error of building URL")
+		}
+		assertEqual(t, 0, calls)
+		if time.Since(start) >= 2*time.Millisecond {
+			t.Error("expected no delay between attempts")
+		}
+	})
+
+	t.Run("does not retry authorization errors", func(t *testing.T) {
+		srv, attempts := FlakyServer(0, http.StatusOK)
+		defer srv.Close()
+
+		core := newCore()
+		core.SetBaseURL(srv.URL)
+		core.SetRetryPolicy(testRetryPolicy())
+
+		calls := 0
+		expected := errors.New("no key")
+		auth := authorizerFunc(func(*http.Request) error {
+			calls++
+			return expected
+		})
+
+		_, err := core.request(context.Background(), http.MethodGet, "/", nil, nil, auth)
+		assertEqual(t, expected, err)
+		assertEqual(t, 1, calls)
+		assertEqual(t, 0, *attempts)
+	})
+}
+
+// authorizerFunc adapts function to Authorizer interface.
+type authorizerFunc func(*http.Request) error
+
+func (f authorizerFunc) Auth(r *http.Request) error {
+	return f(r)
+}
+
+func TestIsNetworkError(t *testing.T) {
+	assertEqual(t, true, isNetworkError(&url.Error{Op: "Get", URL: "/", Err: errors.New("connection reset")}))
+	assertEqual(t, true, isNetworkError(&net.OpError{Op: "dial", Err: errors.New("refused")}))
+	assertEqual(t, false, isNetworkError(&url.Error{Op: "Get", URL: "/", Err: context.Canceled}))
+	assertEqual(t, false, isNetworkError(errors.New("unexpected end of JSON input")))
+
+	_, err := url.Parse("://invalid")
+	assertEqual(t, false, isNetworkError(err))
+}
+
+func TestCorporate_RetrySignsEachAttempt(t *testing.T) {
+	srv, requests := SignedServer(t, `{"name":"John Doe"}`, http.StatusServiceUnavailable, http.StatusOK)
+	defer srv.Close()
+
+	// Delay crosses the second, so repeated signature would not match new X-Time.
+	policy := &RetryPolicy{
+		MaxAttempts:   2,
+		BaseDelay:     1100 * time.Millisecond,
+		RetryStatuses: []int{http.StatusServiceUnavailable},
+	}
+	corporate := newTestCorporate(t, WithBaseURL(srv.URL), WithRetryPolicy(policy))
+
+	if _, err := corporate.User(context.Background(), "reqID"); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, int32(2), atomic.LoadInt32(requests))
+}
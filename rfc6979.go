@@ -0,0 +1,142 @@
+package mono
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// Deterministic generation of ECDSA nonces.
+// See https://tools.ietf.org/html/rfc6979 for details.
+
+// bits2int converts bit string into integer, keeping qlen leftmost bits.
+func bits2int(b []byte, qlen int) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if blen := 8 * len(b); blen > qlen {
+		x.Rsh(x, uint(blen-qlen))
+	}
+
+	return x
+}
+
+// int2octets converts integer into big endian octet string of rlen bits.
+func int2octets(x *big.Int, rlen int) []byte {
+	out := make([]byte, rlen/8)
+	b := x.Bytes()
+	copy(out[len(out)-len(b):], b)
+
+	return out
+}
+
+// bits2octets converts bit string into octet string, reduced modulo q.
+func bits2octets(b []byte, q *big.Int, rlen int) []byte {
+	z := bits2int(b, q.BitLen())
+	if z.Cmp(q) >= 0 {
+		z.Sub(z, q)
+	}
+
+	return int2octets(z, rlen)
+}
+
+// nonceGenerator produces sequence of nonces as described in section 3.2 of RFC 6979.
+type nonceGenerator struct {
+	q    *big.Int
+	k, v []byte
+	mac  func(key []byte, data ...[]byte) []byte
+}
+
+// newNonceGenerator returns generator of nonces for private key x and message hash h1.
+func newNonceGenerator(q, x *big.Int, h1 []byte, newHash func() hash.Hash) *nonceGenerator {
+	qlen := q.BitLen()
+	rlen := 8 * ((qlen + 7) / 8)
+	hlen := newHash().Size()
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		h := hmac.New(newHash, key)
+		for _, d := range data {
+			h.Write(d)
+		}
+		return h.Sum(nil)
+	}
+
+	g := &nonceGenerator{
+		q:   q,
+		k:   make([]byte, hlen),
+		v:   make([]byte, hlen),
+		mac: mac,
+	}
+
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+
+	key := int2octets(x, rlen)
+	msg := bits2octets(h1, q, rlen)
+
+	g.k = mac(g.k, g.v, []byte{0x00}, key, msg)
+	g.v = mac(g.k, g.v)
+	g.k = mac(g.k, g.v, []byte{0x01}, key, msg)
+	g.v = mac(g.k, g.v)
+
+	return g
+}
+
+// next returns next nonce in range [1, q-1].
+func (g *nonceGenerator) next() *big.Int {
+	qlen := g.q.BitLen()
+
+	for {
+		var t []byte
+		for 8*len(t) < qlen {
+			g.v = g.mac(g.k, g.v)
+			t = append(t, g.v...)
+		}
+
+		k := bits2int(t, qlen)
+
+		// Prepare state for the following nonce, in case this one is rejected.
+		g.k = g.mac(g.k, g.v, []byte{0x00})
+		g.v = g.mac(g.k, g.v)
+
+		if k.Sign() > 0 && k.Cmp(g.q) < 0 {
+			return k
+		}
+	}
+}
+
+// signDeterministic signs hash of the message with nonce generated according to RFC 6979.
+// Hash function newHash must be the one used to produce the hash.
+func signDeterministic(key *ecdsa.PrivateKey, hash []byte, newHash func() hash.Hash) (r, s *big.Int, err error) {
+	curve := key.Curve
+	n := curve.Params().N
+	if n.Sign() == 0 {
+		return nil, nil, errors.New("zero parameter")
+	}
+
+	e := bits2int(hash, n.BitLen())
+	nonces := newNonceGenerator(n, key.D, hash, newHash)
+
+	for {
+		k := nonces.next()
+
+		r, _ = curve.ScalarBaseMult(int2octets(k, 8*((n.BitLen()+7)/8)))
+		r.Mod(r, n)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		kInv := new(big.Int).ModInverse(k, n)
+
+		s = new(big.Int).Mul(key.D, r)
+		s.Add(s, e)
+		s.Mul(s, kInv)
+		s.Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		return r, s, nil
+	}
+}
@@ -0,0 +1,153 @@
+package mono
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+)
+
+func hexInt(t *testing.T, s string) *big.Int {
+	x, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		t.Fatalf("invalid hex integer %q", s)
+	}
+
+	return x
+}
+
+func testKey(t *testing.T, curve elliptic.Curve, d string) *ecdsa.PrivateKey {
+	key := &ecdsa.PrivateKey{D: hexInt(t, d)}
+	key.Curve = curve
+	key.X, key.Y = curve.ScalarBaseMult(key.D.Bytes())
+
+	return key
+}
+
+func TestSignDeterministic(t *testing.T) {
+	tests := []struct {
+		name    string
+		curve   elliptic.Curve
+		key     string
+		message string
+		k, r, s string
+		lowS    bool // Published s is in low-s form, while signatures are not normalized.
+	}{
+		{
+			// See section A.2.5 of RFC 6979.
+			name:    "P-256 sample",
+			curve:   elliptic.P256(),
+			key:     "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+			message: "sample",
+			k:       "A6E3C57DD01ABE90086538398355DD4C3B17AA873382B0F24D6129493D8AAD60",
+			r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+			s:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+		},
+		{
+			name:    "P-256 test",
+			curve:   elliptic.P256(),
+			key:     "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+			message: "test",
+			k:       "D16B6AE827F17175E040871A1C7EC3500192C4C92677336EC2537ACAEE0008E0",
+			r:       "F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367",
+			s:       "019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083",
+		},
+		{
+			name:    "secp256k1",
+			curve:   secp256k1,
+			key:     "1",
+			message: "Satoshi Nakamoto",
+			k:       "8F8A276C19F4149656B280621E358CCE24F5F52542772691EE69063B74F15D15",
+			r:       "934B1EA10A4B3C1757E2B0C017D0B6143CE3C9A7E6A4A49860D7A6AB210EE3D8",
+			s:       "2442CE9D2B916064108014783E923EC36B49743E2FFA1C4496F01A512AAFD9E5",
+			lowS:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := testKey(t, tt.curve, tt.key)
+			hash := sha256.Sum256([]byte(tt.message))
+			n := tt.curve.Params().N
+
+			k := newNonceGenerator(n, key.D, hash[:], sha256.New).next()
+			assertEqual(t, hexInt(t, tt.k).String(), k.String())
+
+			r, s, err := signDeterministic(key, hash[:], sha256.New)
+			if err != nil {
+				t.Fatalf("expected error: nil, actual error: %v", err)
+			}
+
+			expected := hexInt(t, tt.s)
+			if tt.lowS {
+				expected.Sub(n, expected)
+			}
+
+			assertEqual(t, hexInt(t, tt.r).String(), r.String())
+			assertEqual(t, expected.String(), s.String())
+		})
+	}
+}
+
+func TestSignTool_SignDeterministic(t *testing.T) {
+	sign := DefaultSignTool()
+
+	key, err := sign.DecodePrivateKey([]byte(testCorporateKey))
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	first, err := sign.SignDeterministic(key, "message")
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	sign.Deterministic = true
+	second, err := sign.Sign(key, "message")
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, first, second)
+
+	if err := sign.VerifyBytes(&key.PublicKey, []byte("message"), first); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	other, err := sign.Sign(key, "another message")
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	if other == first {
+		t.Fatal("expected different signatures for different messages")
+	}
+}
+
+func TestKeySigner_NilOpts(t *testing.T) {
+	sign := DefaultSignTool()
+	sign.Deterministic = true
+
+	key, err := sign.DecodePrivateKey([]byte(testCorporateKey))
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	hash := sha256.Sum256([]byte("message"))
+	signer := sign.Signer(key)
+
+	// SHA-256 is used, when options are not specified.
+	expected, err := signer.Sign(nil, hash[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	actual, err := signer.Sign(nil, hash[:], nil)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, expected, actual)
+}
@@ -0,0 +1,374 @@
+package mono
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// Constant-time arithmetic of secp256k1 curve.
+//
+// Field elements are kept in four 64-bit little-endian limbs and are always fully
+// reduced modulo p = 2²⁵⁶ - 2³² - 977. Points are represented in homogeneous projective
+// coordinates and combined with complete formulas for short Weierstrass curves with a = 0
+// from "Complete addition formulas for prime order elliptic curves" by Renes, Costello and Batina,
+// see https://eprint.iacr.org/2015/1060. Formulas have no exceptional cases, so point at
+// infinity and doubling don't need branches depending on secret data.
+
+// fieldElement is an element of the secp256k1 base field.
+type fieldElement [4]uint64
+
+// fieldReduction is 2²⁵⁶ mod p.
+const fieldReduction = 0x1000003d1
+
+var (
+	fieldPrime = fieldElement{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	fieldOne   = fieldElement{1, 0, 0, 0}
+	// fieldB3 is 3*b, where b = 7 is a parameter of the curve.
+	fieldB3 = fieldElement{21, 0, 0, 0}
+)
+
+// feFromBig sets z = x mod p.
+func feFromBig(x *big.Int) fieldElement {
+	var z fieldElement
+
+	v := x
+	if x.Sign() < 0 || x.Cmp(secp256k1.P) >= 0 {
+		v = new(big.Int).Mod(x, secp256k1.P)
+	}
+
+	b := make([]byte, 32)
+	vb := v.Bytes()
+	copy(b[32-len(vb):], vb)
+
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			z[i] |= uint64(b[31-8*i-j]) << (8 * uint(j))
+		}
+	}
+
+	return z
+}
+
+// big returns z as a big integer.
+func (z *fieldElement) big() *big.Int {
+	b := make([]byte, 32)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			b[31-8*i-j] = byte(z[i] >> (8 * uint(j)))
+		}
+	}
+
+	return new(big.Int).SetBytes(b)
+}
+
+// feSelect sets z = x if cond is 1 and z = y if cond is 0.
+func feSelect(z, x, y *fieldElement, cond uint64) {
+	mask := -cond
+	for i := range z {
+		z[i] = (x[i] & mask) | (y[i] &^ mask)
+	}
+}
+
+// feReduceOnce sets z = x mod p, where x < 2²⁵⁶ + p and carry is 257th bit of x.
+func feReduceOnce(z, x *fieldElement, carry uint64) {
+	var t fieldElement
+	var borrow uint64
+
+	t[0], borrow = bits.Sub64(x[0], fieldPrime[0], 0)
+	t[1], borrow = bits.Sub64(x[1], fieldPrime[1], borrow)
+	t[2], borrow = bits.Sub64(x[2], fieldPrime[2], borrow)
+	t[3], borrow = bits.Sub64(x[3], fieldPrime[3], borrow)
+
+	// Use x - p, if it didn't underflow or x has 257th bit set.
+	feSelect(z, &t, x, carry|(borrow^1))
+}
+
+// feAdd sets z = x + y mod p.
+func feAdd(z, x, y *fieldElement) {
+	var t fieldElement
+	var carry uint64
+
+	t[0], carry = bits.Add64(x[0], y[0], 0)
+	t[1], carry = bits.Add64(x[1], y[1], carry)
+	t[2], carry = bits.Add64(x[2], y[2], carry)
+	t[3], carry = bits.Add64(x[3], y[3], carry)
+
+	feReduceOnce(z, &t, carry)
+}
+
+// feSub sets z = x - y mod p.
+func feSub(z, x, y *fieldElement) {
+	var t fieldElement
+	var borrow, carry uint64
+
+	t[0], borrow = bits.Sub64(x[0], y[0], 0)
+	t[1], borrow = bits.Sub64(x[1], y[1], borrow)
+	t[2], borrow = bits.Sub64(x[2], y[2], borrow)
+	t[3], borrow = bits.Sub64(x[3], y[3], borrow)
+
+	// Add p back, if subtraction underflowed.
+	mask := -borrow
+	z[0], carry = bits.Add64(t[0], fieldPrime[0]&mask, 0)
+	z[1], carry = bits.Add64(t[1], fieldPrime[1]&mask, carry)
+	z[2], carry = bits.Add64(t[2], fieldPrime[2]&mask, carry)
+	z[3], _ = bits.Add64(t[3], fieldPrime[3]&mask, carry)
+}
+
+// feMul sets z = x * y mod p.
+func feMul(z, x, y *fieldElement) {
+	var t [8]uint64
+
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(x[i], y[j])
+
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+
+			t[i+j] = lo
+			carry = hi
+		}
+		t[i+4] = carry
+	}
+
+	// Fold upper half using 2²⁵⁶ ≡ 2³² + 977 (mod p).
+	var r fieldElement
+	var carry uint64
+	for i := 0; i < 4; i++ {
+		hi, lo := bits.Mul64(t[i+4], fieldReduction)
+
+		var c uint64
+		lo, c = bits.Add64(lo, t[i], 0)
+		hi += c
+		lo, c = bits.Add64(lo, carry, 0)
+		hi += c
+
+		r[i] = lo
+		carry = hi
+	}
+
+	// Fold the remaining carry, which is less than 2³⁴.
+	hi, lo := bits.Mul64(carry, fieldReduction)
+	var c uint64
+	r[0], c = bits.Add64(r[0], lo, 0)
+	r[1], c = bits.Add64(r[1], hi, c)
+	r[2], c = bits.Add64(r[2], 0, c)
+	r[3], c = bits.Add64(r[3], 0, c)
+
+	// On overflow the value is small, so this addition can't overflow again.
+	r[0], c = bits.Add64(r[0], c*fieldReduction, 0)
+	r[1], c = bits.Add64(r[1], 0, c)
+	r[2], c = bits.Add64(r[2], 0, c)
+	r[3], _ = bits.Add64(r[3], 0, c)
+
+	feReduceOnce(z, &r, 0)
+}
+
+// feInv sets z = x⁻¹ mod p using Fermat's little theorem, inverse of zero is zero.
+func feInv(z, x *fieldElement) {
+	// Exponent p - 2 is public, so the sequence of operations doesn't depend on x.
+	exp := fieldPrime
+	exp[0] -= 2
+
+	r := fieldOne
+	for i := 3; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			feMul(&r, &r, &r)
+
+			var t fieldElement
+			feMul(&t, &r, x)
+			feSelect(&r, &t, &r, (exp[i]>>uint(j))&1)
+		}
+	}
+
+	*z = r
+}
+
+// projectivePoint is a point of secp256k1 curve in homogeneous projective coordinates (X:Y:Z),
+// which represents affine point (X/Z, Y/Z). Point at infinity is (0:1:0).
+type projectivePoint struct {
+	x, y, z fieldElement
+}
+
+// newIdentity returns point at infinity.
+func newIdentity() projectivePoint {
+	return projectivePoint{y: fieldOne}
+}
+
+// newProjectivePoint converts affine point into projective one, (0, 0) is treated as point at infinity.
+func newProjectivePoint(x, y *big.Int) projectivePoint {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return newIdentity()
+	}
+
+	return projectivePoint{x: feFromBig(x), y: feFromBig(y), z: fieldOne}
+}
+
+// affine returns affine coordinates of the point, point at infinity is returned as (0, 0).
+func (p *projectivePoint) affine() (*big.Int, *big.Int) {
+	var zinv, x, y fieldElement
+
+	feInv(&zinv, &p.z)
+	feMul(&x, &p.x, &zinv)
+	feMul(&y, &p.y, &zinv)
+
+	return x.big(), y.big()
+}
+
+// add sets r = p + q, see algorithm 7 of Renes-Costello-Batina.
+func (r *projectivePoint) add(p, q *projectivePoint) {
+	var t0, t1, t2, t3, t4, x3, y3, z3 fieldElement
+
+	feMul(&t0, &p.x, &q.x)
+	feMul(&t1, &p.y, &q.y)
+	feMul(&t2, &p.z, &q.z)
+	feAdd(&t3, &p.x, &p.y)
+	feAdd(&t4, &q.x, &q.y)
+	feMul(&t3, &t3, &t4)
+	feAdd(&t4, &t0, &t1)
+	feSub(&t3, &t3, &t4)
+	feAdd(&t4, &p.y, &p.z)
+	feAdd(&x3, &q.y, &q.z)
+	feMul(&t4, &t4, &x3)
+	feAdd(&x3, &t1, &t2)
+	feSub(&t4, &t4, &x3)
+	feAdd(&x3, &p.x, &p.z)
+	feAdd(&y3, &q.x, &q.z)
+	feMul(&x3, &x3, &y3)
+	feAdd(&y3, &t0, &t2)
+	feSub(&y3, &x3, &y3)
+	feAdd(&x3, &t0, &t0)
+	feAdd(&t0, &x3, &t0)
+	feMul(&t2, &fieldB3, &t2)
+	feAdd(&z3, &t1, &t2)
+	feSub(&t1, &t1, &t2)
+	feMul(&y3, &fieldB3, &y3)
+	feMul(&x3, &t4, &y3)
+	feMul(&t2, &t3, &t1)
+	feSub(&x3, &t2, &x3)
+	feMul(&y3, &y3, &t0)
+	feMul(&t1, &t1, &z3)
+	feAdd(&y3, &t1, &y3)
+	feMul(&t0, &t0, &t3)
+	feMul(&z3, &z3, &t4)
+	feAdd(&z3, &z3, &t0)
+
+	r.x, r.y, r.z = x3, y3, z3
+}
+
+// double sets r = 2p, see algorithm 9 of Renes-Costello-Batina.
+func (r *projectivePoint) double(p *projectivePoint) {
+	var t0, t1, t2, x3, y3, z3 fieldElement
+
+	feMul(&t0, &p.y, &p.y)
+	feAdd(&z3, &t0, &t0)
+	feAdd(&z3, &z3, &z3)
+	feAdd(&z3, &z3, &z3)
+	feMul(&t1, &p.y, &p.z)
+	feMul(&t2, &p.z, &p.z)
+	feMul(&t2, &fieldB3, &t2)
+	feMul(&x3, &t2, &z3)
+	feAdd(&y3, &t0, &t2)
+	feMul(&z3, &t1, &z3)
+	feAdd(&t1, &t2, &t2)
+	feAdd(&t2, &t1, &t2)
+	feSub(&t0, &t0, &t2)
+	feMul(&y3, &t0, &y3)
+	feAdd(&y3, &x3, &y3)
+	feMul(&t1, &p.x, &p.y)
+	feMul(&x3, &t0, &t1)
+	feAdd(&x3, &x3, &x3)
+
+	r.x, r.y, r.z = x3, y3, z3
+}
+
+// pointTable contains multiples 0*P, 1*P, ..., 15*P of some point P.
+type pointTable [16]projectivePoint
+
+// newPointTable returns table of multiples of the point.
+func newPointTable(p *projectivePoint) *pointTable {
+	table := new(pointTable)
+	table[0] = newIdentity()
+	table[1] = *p
+	for i := 2; i < 16; i++ {
+		table[i].add(&table[i-1], p)
+	}
+
+	return table
+}
+
+// selectInto sets r = n*P in constant time, reading every entry of the table.
+func (table *pointTable) selectInto(r *projectivePoint, n byte) {
+	for i := range table {
+		// cond is 1, when i == n.
+		cond := uint64((uint32(byte(i)^n) - 1) >> 31)
+		feSelect(&r.x, &table[i].x, &r.x, cond)
+		feSelect(&r.y, &table[i].y, &r.y, cond)
+		feSelect(&r.z, &table[i].z, &r.z, cond)
+	}
+}
+
+// scalarMult sets r = k*P, where k is a big endian integer, using fixed 4-bit window.
+func (r *projectivePoint) scalarMult(p *projectivePoint, k []byte) {
+	table := newPointTable(p)
+	acc := newIdentity()
+
+	var t projectivePoint
+	for _, b := range k {
+		for _, n := range [2]byte{b >> 4, b & 0x0f} {
+			acc.double(&acc)
+			acc.double(&acc)
+			acc.double(&acc)
+			acc.double(&acc)
+
+			table.selectInto(&t, n)
+			acc.add(&acc, &t)
+		}
+	}
+
+	*r = acc
+}
+
+var (
+	baseTablesOnce sync.Once
+	// baseTables[i] contains multiples of 16ⁱ*G for each of 64 windows of 256-bit scalar.
+	baseTables *[64]pointTable
+)
+
+func initBaseTables() {
+	baseTables = new([64]pointTable)
+
+	p := newProjectivePoint(secp256k1.Gx, secp256k1.Gy)
+	for i := range baseTables {
+		baseTables[i] = *newPointTable(&p)
+
+		for j := 0; j < 4; j++ {
+			p.double(&p)
+		}
+	}
+}
+
+// scalarBaseMult sets r = k*G, where k is a big endian integer not longer than 32 bytes.
+func (r *projectivePoint) scalarBaseMult(k []byte) {
+	baseTablesOnce.Do(initBaseTables)
+
+	acc := newIdentity()
+
+	var t projectivePoint
+	for i, b := range k {
+		// Windows are numbered from the least significant one.
+		window := 2 * (len(k) - 1 - i)
+
+		baseTables[window+1].selectInto(&t, b>>4)
+		acc.add(&acc, &t)
+		baseTables[window].selectInto(&t, b&0x0f)
+		acc.add(&acc, &t)
+	}
+
+	*r = acc
+}
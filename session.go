@@ -0,0 +1,275 @@
+package mono
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSessionNotFound is returned, when session store has no session with requested ID.
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrPermissionDenied is returned, when client has not granted permission required by the call.
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Session is a request for client's personal data issued by corporate API.
+type Session struct {
+	RequestID    string    `json:"requestId"`    // Token request ID.
+	Permissions  string    `json:"permissions"`  // Requested permissions.
+	CreatedAt    time.Time `json:"createdAt"`    // Time, when request was issued.
+	AuthorizedAt time.Time `json:"authorizedAt"` // Time, when client granted access.
+	LastUsedAt   time.Time `json:"lastUsedAt"`   // Time of the last request with the session.
+}
+
+// NewSession returns new session for the token request with specified permissions.
+func NewSession(reqID string, permissions ...byte) *Session {
+	return &Session{
+		RequestID:   reqID,
+		Permissions: string(permissions),
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// Authorized reports whether client granted access.
+func (s *Session) Authorized() bool {
+	return !s.AuthorizedAt.IsZero()
+}
+
+// Allows reports whether client granted access with specified permission.
+func (s *Session) Allows(permission byte) bool {
+	return s.Authorized() && strings.IndexByte(s.Permissions, permission) >= 0
+}
+
+// SessionStore is an interface for storing corporate sessions.
+type SessionStore interface {
+	// Get returns session by request ID or ErrSessionNotFound.
+	Get(ctx context.Context, reqID string) (*Session, error)
+	// Put saves session, replacing existing one with the same request ID.
+	Put(ctx context.Context, session *Session) error
+}
+
+// MemorySessionStore keeps sessions in memory.
+// It is safe for concurrent use by multiple goroutines.
+type MemorySessionStore struct {
+	mu       sync.RWMutex
+	sessions map[string]Session
+}
+
+// NewMemorySessionStore returns new empty in-memory session store.
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions: make(map[string]Session),
+	}
+}
+
+// Get returns session by request ID or ErrSessionNotFound.
+func (s *MemorySessionStore) Get(ctx context.Context, reqID string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[reqID]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	return &session, nil
+}
+
+// Put saves session, replacing existing one with the same request ID.
+func (s *MemorySessionStore) Put(ctx context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.RequestID] = *session
+	return nil
+}
+
+// FileSessionStore keeps sessions in JSON file, which is rewritten on each change.
+// It is safe for concurrent use by multiple goroutines of the single process.
+type FileSessionStore struct {
+	mu       sync.RWMutex
+	path     string
+	sessions map[string]Session
+}
+
+// NewFileSessionStore returns session store backed by file at path.
+// Sessions are loaded from the file, if it exists.
+func NewFileSessionStore(path string) (*FileSessionStore, error) {
+	store := &FileSessionStore{
+		path:     path,
+		sessions: make(map[string]Session),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &store.sessions); err != nil {
+		return nil, fmt.Errorf("failed to decode sessions from %s: %w", path, err)
+	}
+
+	return store, nil
+}
+
+// Get returns session by request ID or ErrSessionNotFound.
+func (s *FileSessionStore) Get(ctx context.Context, reqID string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[reqID]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	return &session, nil
+}
+
+// Put saves session, replacing existing one with the same request ID.
+func (s *FileSessionStore) Put(ctx context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.sessions[session.RequestID]
+	s.sessions[session.RequestID] = *session
+
+	if err := s.save(); err != nil {
+		// Keep memory consistent with the file.
+		if existed {
+			s.sessions[session.RequestID] = previous
+		} else {
+			delete(s.sessions, session.RequestID)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// save atomically replaces file with current sessions.
+func (s *FileSessionStore) save() error {
+	data, err := json.MarshalIndent(s.sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// CorporateSession gives access to client's data within permissions granted for the request ID.
+// Calls are refused with ErrPermissionDenied, when client has not granted required permission,
+// each successful call updates LastUsedAt of the session.
+type CorporateSession struct {
+	corporate *Corporate
+	reqID     string
+}
+
+// RequestID returns token request ID of the session.
+func (cs *CorporateSession) RequestID() string {
+	return cs.reqID
+}
+
+// Info returns current state of the session from the store.
+func (cs *CorporateSession) Info(ctx context.Context) (*Session, error) {
+	return cs.corporate.sessions.Get(ctx, cs.reqID)
+}
+
+// acquire checks, that client granted permission.
+func (cs *CorporateSession) acquire(ctx context.Context, permission byte) error {
+	session, err := cs.Info(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !session.Allows(permission) {
+		return fmt.Errorf("session %s does not allow %q: %w", cs.reqID, permission, ErrPermissionDenied)
+	}
+
+	return nil
+}
+
+// touch records time of the last use of the session.
+// Session is read again, since it might be changed during the call, failure is only logged,
+// so data already received is not lost.
+func (cs *CorporateSession) touch(ctx context.Context) {
+	err := cs.corporate.updateSession(ctx, cs.reqID, func(session *Session) bool {
+		session.LastUsedAt = time.Now().UTC()
+		return true
+	})
+	if err != nil {
+		cs.corporate.authCore.logf("mono: failed to update session %s: %v", cs.reqID, err)
+	}
+}
+
+// User returns user personal information, requires PersonalPermission.
+func (cs *CorporateSession) User(ctx context.Context) (*UserInfo, error) {
+	if err := cs.acquire(ctx, PersonalPermission); err != nil {
+		return nil, err
+	}
+
+	user, err := cs.corporate.User(ctx, cs.reqID)
+	if err != nil {
+		return nil, err
+	}
+
+	cs.touch(ctx)
+
+	return user, nil
+}
+
+// Transactions returns list of transactions from {from} till {to} time, requires StatementPermission.
+func (cs *CorporateSession) Transactions(ctx context.Context, account string, from, to time.Time) ([]Transaction, error) {
+	if err := cs.acquire(ctx, StatementPermission); err != nil {
+		return nil, err
+	}
+
+	transactions, err := cs.corporate.Transactions(ctx, cs.reqID, account, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	cs.touch(ctx)
+
+	return transactions, nil
+}
+
+// AllTransactions returns complete list of transactions from {from} till {to} time, requires StatementPermission.
+func (cs *CorporateSession) AllTransactions(ctx context.Context, account string, from, to time.Time) ([]Transaction, error) {
+	if err := cs.acquire(ctx, StatementPermission); err != nil {
+		return nil, err
+	}
+
+	transactions, err := cs.corporate.AllTransactions(ctx, cs.reqID, account, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	cs.touch(ctx)
+
+	return transactions, nil
+}
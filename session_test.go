@@ -0,0 +1,215 @@
+package mono
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testSessionStore(t *testing.T, store SessionStore) {
+	ctx := context.Background()
+
+	_, err := store.Get(ctx, "reqID")
+	assertEqual(t, ErrSessionNotFound, err)
+
+	session := NewSession("reqID", StatementPermission, PersonalPermission)
+	if err := store.Put(ctx, session); err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	actual, err := store.Get(ctx, "reqID")
+	assertEqual(t, nil, err)
+	assertEqual(t, session, actual)
+
+	// Stored session is not affected by changes of returned one.
+	actual.Permissions = ""
+	actual, _ = store.Get(ctx, "reqID")
+	assertEqual(t, "sp", actual.Permissions)
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	testSessionStore(t, NewMemorySessionStore())
+}
+
+func TestFileSessionStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mono")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sessions.json")
+
+	store, err := NewFileSessionStore(path)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+	testSessionStore(t, store)
+
+	t.Run("loads saved sessions", func(t *testing.T) {
+		store, err := NewFileSessionStore(path)
+		if err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+
+		session, err := store.Get(context.Background(), "reqID")
+		assertEqual(t, nil, err)
+		assertEqual(t, "sp", session.Permissions)
+	})
+
+	t.Run("fails on invalid file", func(t *testing.T) {
+		invalid := filepath.Join(dir, "invalid.json")
+		if err := ioutil.WriteFile(invalid, []byte("{"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := NewFileSessionStore(invalid)
+		if err == nil {
+			t.Error("expected decoding error")
+		}
+	})
+}
+
+func TestCorporate_Session(t *testing.T) {
+	srv, _ := FakeServer(`{"name":"John Doe"}`, http.StatusOK)
+	defer srv.Close()
+
+	corporate := newTestCorporate(t, WithBaseURL(srv.URL))
+	store := corporate.SessionStore()
+	ctx := context.Background()
+
+	if err := store.Put(ctx, NewSession("reqID", PersonalPermission)); err != nil {
+		t.Fatal(err)
+	}
+
+	session := corporate.Session("reqID")
+
+	t.Run("refuses calls before authorization", func(t *testing.T) {
+		_, err := session.User(ctx)
+		if !errors.Is(err, ErrPermissionDenied) {
+			t.Errorf("expected %v, got %v", ErrPermissionDenied, err)
+		}
+	})
+
+	t.Run("authorizes session on check", func(t *testing.T) {
+		_, err := corporate.CheckAuth(ctx, "reqID")
+		assertEqual(t, nil, err)
+
+		info, _ := session.Info(ctx)
+		assertEqual(t, true, info.Authorized())
+	})
+
+	t.Run("allows granted permissions", func(t *testing.T) {
+		user, err := session.User(ctx)
+		if err != nil {
+			t.Fatalf("expected error: nil, actual error: %v", err)
+		}
+		assertEqual(t, "John Doe", user.Name)
+
+		info, _ := session.Info(ctx)
+		if time.Since(info.LastUsedAt) > time.Minute {
+			t.Errorf("expected last used time to be updated, got %s", info.LastUsedAt)
+		}
+	})
+
+	t.Run("refuses other permissions", func(t *testing.T) {
+		_, err := session.Transactions(ctx, "acc", time.Now().Add(-time.Hour), time.Now())
+		if !errors.Is(err, ErrPermissionDenied) {
+			t.Errorf("expected %v, got %v", ErrPermissionDenied, err)
+		}
+	})
+
+	t.Run("refuses unknown session", func(t *testing.T) {
+		_, err := corporate.Session("unknown").User(ctx)
+		assertEqual(t, ErrSessionNotFound, err)
+	})
+}
+
+// failingSessionStore fails to save sessions.
+type failingSessionStore struct {
+	SessionStore
+}
+
+func (s *failingSessionStore) Put(ctx context.Context, session *Session) error {
+	return errors.New("disk is full")
+}
+
+func TestCorporateSession_Touch(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySessionStore()
+
+	// Session is changed concurrently, while request is in progress.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, _ := store.Get(ctx, "reqID")
+		session.Permissions = "ps"
+		_ = store.Put(ctx, session)
+
+		_, _ = w.Write([]byte(`{"name":"John Doe"}`))
+	}))
+	defer srv.Close()
+
+	corporate := newTestCorporate(t, WithBaseURL(srv.URL))
+	corporate.SetSessionStore(store)
+
+	session := NewSession("reqID", PersonalPermission)
+	session.AuthorizedAt = time.Now().UTC()
+	if err := store.Put(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("keeps concurrent changes", func(t *testing.T) {
+		_, err := corporate.Session("reqID").User(ctx)
+		assertEqual(t, nil, err)
+
+		info, _ := store.Get(ctx, "reqID")
+		assertEqual(t, "ps", info.Permissions)
+		assertEqual(t, false, info.LastUsedAt.IsZero())
+	})
+
+	t.Run("returns data, when session is not saved", func(t *testing.T) {
+		corporate.SetSessionStore(&failingSessionStore{store})
+
+		user, err := corporate.Session("reqID").User(ctx)
+		assertEqual(t, nil, err)
+		assertEqual(t, "John Doe", user.Name)
+	})
+}
+
+func TestCorporate_Auth(t *testing.T) {
+	srv, rr := FakeServer(`{"tokenRequestId":"reqID","acceptUrl":"https://mbnk.app/auth/reqID"}`, http.StatusOK)
+	defer srv.Close()
+
+	corporate := newTestCorporate(t, WithBaseURL(srv.URL))
+
+	request, err := corporate.Auth(context.Background(), "https://example.com/callback", StatementPermission)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	rr.AssertHeaders(t, map[string]string{
+		"X-Permissions": "s",
+		"X-Callback":    "https://example.com/callback",
+	})
+	assertEqual(t, "reqID", request.TokenRequestID)
+
+	session, err := corporate.SessionStore().Get(context.Background(), "reqID")
+	assertEqual(t, nil, err)
+	assertEqual(t, "s", session.Permissions)
+	assertEqual(t, false, session.Authorized())
+
+	t.Run("returns request, when session is not saved", func(t *testing.T) {
+		corporate.SetSessionStore(&failingSessionStore{NewMemorySessionStore()})
+
+		request, err := corporate.Auth(context.Background(), "https://example.com/callback", StatementPermission)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		assertEqual(t, "reqID", request.TokenRequestID)
+	})
+}
@@ -0,0 +1,106 @@
+package mono
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// MaxStatementPeriod is the longest time range accepted by a single statement request.
+	MaxStatementPeriod = 31*24*time.Hour + time.Hour
+	// MaxStatementItems is the maximum number of transactions returned by a single statement request.
+	MaxStatementItems = 500
+)
+
+// ErrTooManyTransactions is returned, when a single second contains more transactions than
+// a statement request returns, so the window can't be paged without losing data.
+var ErrTooManyTransactions = errors.New("too many transactions at the same time")
+
+// statementRetryDelay is delay before repeating statement request rejected with 429 Too Many Requests,
+// when response doesn't specify one. MonoBank allows one statement request per minute.
+var statementRetryDelay = time.Minute
+
+// maxStatementRetries is the number of times rejected statement request is repeated.
+const maxStatementRetries = 3
+
+// statementFetcher requests a single statement window from MonoBank API.
+type statementFetcher func(ctx context.Context, from, to time.Time) ([]Transaction, error)
+
+// fetchWindow requests statement window, waiting and repeating the request, when it's rejected
+// because of rate limit, so windows are not lost without rate limiter.
+func fetchWindow(ctx context.Context, from, to time.Time, fetch statementFetcher) ([]Transaction, error) {
+	for retries := 0; ; retries++ {
+		transactions, err := fetch(ctx, from, to)
+		if !errors.Is(err, ErrTooManyRequests) || retries >= maxStatementRetries {
+			return transactions, err
+		}
+
+		delay := statementRetryDelay
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			delay = apiErr.RetryAfter
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// allTransactions splits range from {from} till {to} into windows accepted by MonoBank API,
+// pages through truncated windows and returns de-duplicated transactions in chronological order.
+// Windows rejected because of rate limit are requested again after delay.
+func allTransactions(ctx context.Context, from, to time.Time, fetch statementFetcher) ([]Transaction, error) {
+	seen := make(map[string]struct{})
+	result := make([]Transaction, 0)
+
+	for start := from; start.Before(to); start = start.Add(MaxStatementPeriod) {
+		end := start.Add(MaxStatementPeriod)
+		if end.After(to) {
+			end = to
+		}
+
+		for {
+			transactions, err := fetchWindow(ctx, start, end, fetch)
+			if err != nil {
+				return nil, err
+			}
+
+			oldest := end
+			for _, t := range transactions {
+				if t.Time.Before(oldest) {
+					oldest = t.Time.Time
+				}
+
+				if _, ok := seen[t.ID]; ok {
+					continue
+				}
+
+				seen[t.ID] = struct{}{}
+				result = append(result, t)
+			}
+
+			// Window is complete, when API returned less than maximum amount of items.
+			// Otherwise, request the rest of the window up to the oldest received transaction.
+			if len(transactions) < MaxStatementItems {
+				break
+			}
+
+			// Window, which can't be narrowed anymore, would silently lose transactions.
+			if !oldest.Before(end) {
+				return nil, fmt.Errorf("%w: %d at %s", ErrTooManyTransactions, len(transactions), end.UTC())
+			}
+
+			end = oldest
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time.Time)
+	})
+
+	return result, nil
+}
@@ -0,0 +1,191 @@
+package mono
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeStatement emulates MonoBank statement endpoint with it's limitations.
+// It may be called from server goroutine, so violations are returned as errors.
+func fakeStatement(transactions []Transaction) statementFetcher {
+	return func(ctx context.Context, from, to time.Time) ([]Transaction, error) {
+		if to.Sub(from) > MaxStatementPeriod {
+			return nil, fmt.Errorf("window %s - %s exceeds maximum period", from, to)
+		}
+
+		result := make([]Transaction, 0)
+		// API returns transactions starting from the newest.
+		for i := len(transactions) - 1; i >= 0; i-- {
+			tx := transactions[i]
+			if tx.Time.Before(from) || tx.Time.After(to) {
+				continue
+			}
+
+			result = append(result, tx)
+			if len(result) == MaxStatementItems {
+				break
+			}
+		}
+
+		return result, nil
+	}
+}
+
+func TestAllTransactions(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(365 * 24 * time.Hour)
+
+	transactions := make([]Transaction, 0)
+	for tm := from; tm.Before(to); tm = tm.Add(10 * time.Minute) {
+		transactions = append(transactions, Transaction{
+			ID:   strconv.Itoa(len(transactions)),
+			Time: Time{tm},
+		})
+	}
+
+	actual, err := allTransactions(context.Background(), from, to, fakeStatement(transactions))
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, transactions, actual)
+}
+
+func TestAllTransactions_Error(t *testing.T) {
+	expected := errors.New("failure")
+
+	fetch := func(ctx context.Context, from, to time.Time) ([]Transaction, error) {
+		return nil, expected
+	}
+
+	_, err := allTransactions(context.Background(), time.Unix(0, 0), time.Unix(3600, 0), fetch)
+	assertEqual(t, expected, err)
+}
+
+func TestAllTransactions_TooManyRequests(t *testing.T) {
+	defer func(delay time.Duration) {
+		statementRetryDelay = delay
+	}(statementRetryDelay)
+	statementRetryDelay = time.Millisecond
+
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * MaxStatementPeriod)
+
+	transactions := []Transaction{
+		{ID: "1", Time: Time{from.Add(time.Hour)}},
+		{ID: "2", Time: Time{to.Add(-time.Hour)}},
+	}
+
+	// The second window is rejected, like without rate limiter.
+	requests := 0
+	statement := fakeStatement(transactions)
+	fetch := func(ctx context.Context, from, to time.Time) ([]Transaction, error) {
+		requests++
+		if requests == 2 {
+			return nil, &APIError{StatusCode: http.StatusTooManyRequests}
+		}
+
+		return statement(ctx, from, to)
+	}
+
+	actual, err := allTransactions(context.Background(), from, to, fetch)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, transactions, actual)
+	assertEqual(t, 3, requests)
+
+	t.Run("gives up after retries", func(t *testing.T) {
+		requests := 0
+		fetch := func(ctx context.Context, from, to time.Time) ([]Transaction, error) {
+			requests++
+			return nil, &APIError{StatusCode: http.StatusTooManyRequests}
+		}
+
+		_, err := allTransactions(context.Background(), from, to, fetch)
+		if !errors.Is(err, ErrTooManyRequests) {
+			t.Errorf("expected error: %v, actual error: %v", ErrTooManyRequests, err)
+		}
+		assertEqual(t, maxStatementRetries+1, requests)
+	})
+}
+
+func TestAllTransactions_TooManyAtTheSameTime(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	transactions := make([]Transaction, 0)
+	for i := 0; i <= MaxStatementItems; i++ {
+		transactions = append(transactions, Transaction{
+			ID:   strconv.Itoa(i),
+			Time: Time{to},
+		})
+	}
+
+	_, err := allTransactions(context.Background(), from, to, fakeStatement(transactions))
+	if !errors.Is(err, ErrTooManyTransactions) {
+		t.Errorf("expected error: %v, actual error: %v", ErrTooManyTransactions, err)
+	}
+}
+
+func TestPersonal_AllTransactions(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(90 * 24 * time.Hour)
+
+	transactions := make([]Transaction, 0)
+	for tm := from; tm.Before(to); tm = tm.Add(24 * time.Hour) {
+		transactions = append(transactions, Transaction{
+			ID:   strconv.Itoa(len(transactions)),
+			Time: Time{tm.UTC()},
+		})
+	}
+
+	requests := 0
+	fetch := fakeStatement(transactions)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+
+		var account string
+		var from, to int64
+		path := strings.ReplaceAll(req.URL.Path, "/", " ")
+		if _, err := fmt.Sscanf(path, " personal statement %s %d %d", &account, &from, &to); err != nil {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		assertEqual(t, "acc", account)
+
+		result, err := fetch(req.Context(), time.Unix(from, 0), time.Unix(to, 0))
+		if err != nil {
+			t.Error(err)
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewEncoder(rw).Encode(result); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	personal := NewPersonal("token")
+	personal.SetBaseURL(srv.URL)
+	personal.SetRateLimiter(nil)
+
+	actual, err := personal.AllTransactions(context.Background(), "acc", from, to)
+	if err != nil {
+		t.Fatalf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, transactions, actual)
+	assertEqual(t, 3, requests)
+}
@@ -6,19 +6,36 @@ import (
 )
 
 // Time defines a timestamp encoded as epoch seconds in JSON
+// RFC 3339 strings are accepted on decoding, but time is always encoded as epoch seconds,
+// so decoded and encoded JSON of acquiring API differs.
 // Most of it's code taken from https://github.com/pieterclaerhout/example-json-unixtimestamp/blob/master/cmd/example-json-timestamp/time.go
 type Time struct {
 	time.Time
 }
 
 // MarshalJSON is used to convert the timestamp to JSON
+// Zero time is encoded as 0, like missing timestamps are sent by MonoBank.
 func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+
 	return []byte(strconv.FormatInt(t.Unix(), 10)), nil
 }
 
 // UnmarshalJSON is used to convert the timestamp from JSON
+// Acquiring API encodes time as RFC 3339 string, which is also accepted.
 func (t *Time) UnmarshalJSON(s []byte) (err error) {
 	r := string(s)
+	if len(r) > 1 && r[0] == '"' {
+		var q time.Time
+		if err := q.UnmarshalJSON(s); err != nil {
+			return err
+		}
+		*t = Time{q.UTC()}
+		return nil
+	}
+
 	q, err := strconv.ParseInt(r, 10, 64)
 	if err != nil {
 		return err
@@ -39,3 +39,31 @@ func TestTime_MarshalJson(t *testing.T) {
 		t.Errorf("expected data: %v, actual data: %v", expectedJson, string(actualJson))
 	}
 }
+
+func TestTime_UnmarshalJSON_RFC3339(t *testing.T) {
+	jsonString := []byte(`{"time": "2020-02-29T18:24:03Z"}`)
+	expectedData := struct{ Time Time }{
+		Time: Time{time.Date(2020, 2, 29, 18, 24, 03, 00, time.UTC)},
+	}
+
+	var actualData struct{ Time Time }
+	err := json.Unmarshal(jsonString, &actualData)
+
+	if err != nil {
+		t.Errorf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, expectedData, actualData)
+}
+
+func TestTime_MarshalJSON_Zero(t *testing.T) {
+	actualJson, err := json.Marshal(struct {
+		Time Time `json:"time"`
+	}{})
+
+	if err != nil {
+		t.Errorf("expected error: nil, actual error: %v", err)
+	}
+
+	assertEqual(t, `{"time":0}`, string(actualJson))
+}
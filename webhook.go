@@ -0,0 +1,91 @@
+package mono
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// StatementItemEventType is type of WebHook event about new transaction.
+const StatementItemEventType = "StatementItem"
+
+// maxWebhookSize limits size of WebHook request body.
+const maxWebhookSize = 1 << 20
+
+// StatementItemEvent is a notification about new transaction on the account.
+// See https://api.monobank.ua/docs/#operation--personal-webhook-post for details.
+type StatementItemEvent struct {
+	Account       string      `json:"account"`       // Account identifier.
+	StatementItem Transaction `json:"statementItem"` // New transaction.
+}
+
+// webhookEvent is a payload sent by MonoBank to the WebHook URL.
+type webhookEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// WebhookHandler receives events sent by MonoBank to the URL set with SetWebHook.
+// It answers validation request and dispatches events to registered callbacks.
+// Callbacks are invoked in separate goroutine, so MonoBank gets response as fast as possible.
+type WebhookHandler struct {
+	mu            sync.RWMutex
+	statementItem []func(ctx context.Context, event StatementItemEvent)
+}
+
+// NewWebhookHandler returns new handler of personal WebHook events.
+func NewWebhookHandler() *WebhookHandler {
+	return new(WebhookHandler)
+}
+
+// OnStatementItem registers callback for new transactions.
+func (h *WebhookHandler) OnStatementItem(fn func(ctx context.Context, event StatementItemEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.statementItem = append(h.statementItem, fn)
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		// MonoBank validates URL with GET request before saving it.
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var event webhookEvent
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookSize)).Decode(&event); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch event.Type {
+	case StatementItemEventType:
+		var data StatementItemEvent
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		h.mu.RLock()
+		callbacks := make([]func(context.Context, StatementItemEvent), len(h.statementItem))
+		copy(callbacks, h.statementItem)
+		h.mu.RUnlock()
+
+		go func() {
+			for _, fn := range callbacks {
+				fn(context.Background(), data)
+			}
+		}()
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
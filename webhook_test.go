@@ -0,0 +1,80 @@
+package mono
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	handler := NewWebhookHandler()
+
+	events := make(chan StatementItemEvent, 1)
+	handler.OnStatementItem(func(ctx context.Context, event StatementItemEvent) {
+		events <- event
+	})
+
+	t.Run("answers validation request", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+
+		assertEqual(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("dispatches statement item", func(t *testing.T) {
+		payload := `{
+			"type": "StatementItem",
+			"data": {
+				"account": "kKGVoZuHWzqVoZuH",
+				"statementItem": {
+					"id": "ZuHWzqkKGVo=",
+					"time": 1554466347,
+					"description": "Покупка щастя",
+					"mcc": 7997,
+					"amount": -95000,
+					"operationAmount": -95000,
+					"currencyCode": 980,
+					"balance": 10050000
+				}
+			}
+		}`
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload)))
+		assertEqual(t, http.StatusOK, rec.Code)
+
+		select {
+		case event := <-events:
+			assertEqual(t, "kKGVoZuHWzqVoZuH", event.Account)
+			assertEqual(t, "ZuHWzqkKGVo=", event.StatementItem.ID)
+			assertEqual(t, Time{time.Unix(1554466347, 0).UTC()}, event.StatementItem.Time)
+			assertEqual(t, int64(-95000), event.StatementItem.Amount)
+		case <-time.After(time.Second):
+			t.Fatal("callback was not called")
+		}
+	})
+
+	t.Run("ignores unknown events", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"type":"Unknown"}`)))
+
+		assertEqual(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("rejects invalid payload", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{`)))
+
+		assertEqual(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("rejects other methods", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/webhook", nil))
+
+		assertEqual(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}